@@ -27,7 +27,7 @@ func main() {
 
 	fmt.Println("RedisGk initialized successfully")
 	fmt.Println("Listening for key events via keyevent channels...")
-	fmt.Println("Channels: __keyevent@0__:expire, __keyevent@0__:expired, __keyevent@0__:set, __keyevent@0__:del")
+	fmt.Println("Channels: __keyevent@0__:expire, __keyevent@0__:expired, __keyevent@0__:set, __keyevent@0__:del, __keyevent@0__:rename_from, __keyevent@0__:rename_to")
 
 	// Get channel for listening to events
 	eventChan := redisGk.ListenChannelKeyEventManager()
@@ -82,6 +82,10 @@ func main() {
 				fmt.Printf("🟢 CREATED: Key '%s' was created/updated\n", event.Key)
 			case redisgklib.EventTypeDeleted:
 				fmt.Printf("🗑️ DELETED: Key '%s' was deleted\n", event.Key)
+			case redisgklib.EventTypeRenamedFrom:
+				fmt.Printf("✏️ RENAMED FROM: Key '%s' was renamed\n", event.Key)
+			case redisgklib.EventTypeRenamedTo:
+				fmt.Printf("✏️ RENAMED TO: Key '%s' is the new name\n", event.Key)
 			default:
 				fmt.Printf("❓ UNKNOWN: Key '%s' event type unknown\n", event.Key)
 			}
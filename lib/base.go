@@ -1,6 +1,7 @@
 package redisgklib
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/redis/go-redis/v9"
@@ -22,3 +23,137 @@ func (v *RedisGk) getKeyValue(key string) (string, error) {
 
 	return result, nil
 }
+
+// convertKeyPaths converts several key paths for multi-key commands
+func (v *RedisGk) convertKeyPaths(keyPaths [][]string) ([]string, error) {
+	keys := make([]string, 0, len(keyPaths))
+	for i, keyPath := range keyPaths {
+		keyP, err := v.slicePathsConvertor(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("key conversion error %d: %w", i, err)
+		}
+		keys = append(keys, keyP)
+	}
+	return keys, nil
+}
+
+// scanCount returns SCAN batch size from optional argument
+func scanCount(countRes []int64) int64 {
+	var count int64 = 100 // Default value
+	if len(countRes) > 0 {
+		count = countRes[0]
+		if count <= 0 {
+			count = 100
+		}
+	}
+	return count
+}
+
+// scanKeysPage performs one SCAN call, filtered by key type if it is set
+func (v *RedisGk) scanKeysPage(
+	ctx context.Context,
+	pattern string,
+	cursor uint64,
+	count int64,
+	keyType string,
+) ([]string, uint64, error) {
+	var keys []string
+	var err error
+
+	if keyType != "" {
+		keys, cursor, err = v.redisClient.ScanType(ctx, cursor, pattern, count, keyType).Result()
+	} else {
+		keys, cursor, err = v.redisClient.Scan(ctx, cursor, pattern, count).Result()
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("key scanning error: %w", err)
+	}
+
+	return keys, cursor, nil
+}
+
+// countByPattern counts keys matching the pattern without keeping them
+func (v *RedisGk) countByPattern(ctx context.Context, pattern string) (int64, error) {
+	var total int64
+	var cursor uint64
+
+	for {
+		keys, next, err := v.scanKeysPage(ctx, pattern, cursor, 1000, "")
+		if err != nil {
+			return 0, err
+		}
+
+		total += int64(len(keys))
+
+		cursor = next
+		if cursor == 0 {
+			return total, nil
+		}
+	}
+}
+
+// mget gets values of keys with one MGET
+// A reply whose length doesn't match the keys (e.g. from a misbehaving proxy)
+// is returned as an error, so callers can index values by key position
+func (v *RedisGk) mget(ctx context.Context, keys []string) ([]any, error) {
+	values, err := v.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting values: %w", err)
+	}
+	if len(values) != len(keys) {
+		return nil, fmt.Errorf("MGET returned %d values for %d keys", len(values), len(keys))
+	}
+	return values, nil
+}
+
+// scanStringValues scans keys by pattern and calls fn for each string value
+// Values are fetched with one MGET per SCAN batch, missing and non-string keys are skipped
+func (v *RedisGk) scanStringValues(
+	ctx context.Context,
+	pattern string,
+	count int64,
+	fn func(key, value string),
+) error {
+	return v.scanValues(ctx, pattern, count, func(key, value string, found bool) {
+		if found {
+			fn(key, value)
+		}
+	})
+}
+
+// scanValues works like scanStringValues, but also calls fn with found = false for keys
+// deleted after SCAN or holding a non-string type
+func (v *RedisGk) scanValues(
+	ctx context.Context,
+	pattern string,
+	count int64,
+	fn func(key, value string, found bool),
+) error {
+	var cursor uint64
+
+	// Process results directly without additional goroutines
+	for {
+		keys, next, err := v.redisClient.Scan(ctx, cursor, pattern, count).Result()
+		if err != nil {
+			return fmt.Errorf("key scanning error: %w", err)
+		}
+		cursor = next
+
+		if len(keys) > 0 {
+			// Get values for all keys in one request
+			values, err := v.mget(ctx, keys)
+			if err != nil {
+				return err
+			}
+
+			for i, value := range values {
+				str, ok := value.(string)
+				fn(keys[i], str, ok)
+			}
+		}
+
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// circuitBreaker - go-redis hook failing commands on a key fast while Redis keeps failing on it
+// Each key has its own circuit, keyless commands such as PING share the one under ""
+type circuitBreaker struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+	logger    Logger
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit - breaker state of a single key
+// Closed circuits without failures are removed, so only failing keys are kept
+type circuit struct {
+	state        CircuitState
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool
+}
+
+// newCircuitBreaker creates a new circuit breaker, nil if the threshold is not set
+func newCircuitBreaker(opts RedisAdditionalOptions) *circuitBreaker {
+	if opts.CircuitBreakerThreshold <= 0 {
+		return nil
+	}
+
+	cooldown := opts.CircuitBreakerCooldown
+	if cooldown <= 0 {
+		cooldown = 5 * time.Second
+	}
+
+	return &circuitBreaker{
+		threshold: opts.CircuitBreakerThreshold,
+		window:    opts.CircuitBreakerWindow,
+		cooldown:  cooldown,
+		logger:    opts.Logger,
+		circuits:  make(map[string]*circuit),
+	}
+}
+
+// allow reports whether a command on the key may be sent
+// After the cooldown one probe command at a time is let through
+func (cb *circuitBreaker) allow(key string) bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c, ok := cb.circuits[key]
+	if !ok {
+		return true
+	}
+
+	switch c.state {
+	case CircuitOpen:
+		if time.Since(c.openedAt) < cb.cooldown {
+			return false
+		}
+		c.state = CircuitHalfOpen
+		c.probing = true
+		return true
+	case CircuitHalfOpen:
+		if c.probing {
+			return false
+		}
+		c.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// release gives back a probe let through by allow without sending a command
+func (cb *circuitBreaker) release(key string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if c, ok := cb.circuits[key]; ok {
+		c.probing = false
+	}
+}
+
+// record updates the circuit of the key with the result of a sent command
+func (cb *circuitBreaker) record(ctx context.Context, key string, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c, ok := cb.circuits[key]
+
+	// Cancellation by the caller and commands rejected by the shard router say nothing about Redis
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrCrossShard) {
+		if ok {
+			c.probing = false
+		}
+		return
+	}
+
+	if !isConnectionFailure(err) {
+		if ok && c.state != CircuitClosed {
+			logf(cb.logger, ctx, "redisgk: circuit breaker closed for key %q", key)
+		}
+		delete(cb.circuits, key)
+		return
+	}
+
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		cb.circuits[key] = c
+	}
+
+	now := time.Now()
+	if c.state == CircuitHalfOpen {
+		c.state = CircuitOpen
+		c.openedAt = now
+		c.probing = false
+		logf(cb.logger, ctx, "redisgk: circuit breaker probe failed for key %q, open for %s: %v", key, cb.cooldown, err)
+		return
+	}
+
+	if c.failures == 0 || (cb.window > 0 && now.Sub(c.firstFailure) > cb.window) {
+		c.failures = 0
+		c.firstFailure = now
+	}
+	c.failures++
+
+	if c.state == CircuitClosed && c.failures >= cb.threshold {
+		c.state = CircuitOpen
+		c.openedAt = now
+		logf(cb.logger, ctx, "redisgk: circuit breaker open for key %q for %s after %d failures: %v", key, cb.cooldown, c.failures, err)
+	}
+}
+
+// getState returns the most severe state over all circuits
+func (cb *circuitBreaker) getState() CircuitState {
+	if cb == nil {
+		return ""
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	state := CircuitClosed
+	for _, c := range cb.circuits {
+		switch c.state {
+		case CircuitOpen:
+			return CircuitOpen
+		case CircuitHalfOpen:
+			state = CircuitHalfOpen
+		}
+	}
+	return state
+}
+
+// openKeys returns the keys whose circuit is not closed, "" stands for keyless commands
+func (cb *circuitBreaker) openKeys() []string {
+	if cb == nil {
+		return nil
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	var keys []string
+	for key, c := range cb.circuits {
+		if c.state != CircuitClosed {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isConnectionFailure reports whether the error means Redis is unavailable or too slow
+// Missing keys and error replies like WRONGTYPE don't count
+func isConnectionFailure(err error) bool {
+	if err == nil || err == redis.Nil {
+		return false
+	}
+	// Cancellation by the caller and commands rejected by the shard router say nothing about Redis
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrCrossShard) {
+		return false
+	}
+	var redisErr redis.Error
+	return !errors.As(err, &redisErr)
+}
+
+// DialHook passes dialing through unchanged
+func (cb *circuitBreaker) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook rejects the command while the circuit of its key is open
+func (cb *circuitBreaker) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		key := commandKey(cmd)
+		if !cb.allow(key) {
+			cmd.SetErr(ErrCircuitOpen)
+			return ErrCircuitOpen
+		}
+		err := next(ctx, cmd)
+		cb.record(ctx, key, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook rejects the pipeline while the circuit of any of its keys is open
+func (cb *circuitBreaker) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		keys := pipelineKeys(cmds)
+		for i, key := range keys {
+			if cb.allow(key) {
+				continue
+			}
+			for _, allowed := range keys[:i] {
+				cb.release(allowed)
+			}
+			for _, cmd := range cmds {
+				cmd.SetErr(ErrCircuitOpen)
+			}
+			return ErrCircuitOpen
+		}
+		err := next(ctx, cmds)
+		for _, key := range keys {
+			cb.record(ctx, key, err)
+		}
+		return err
+	}
+}
+
+// pipelineKeys returns the distinct first keys of the pipelined commands
+func pipelineKeys(cmds []redis.Cmder) []string {
+	seen := make(map[string]bool, len(cmds))
+	keys := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		key := commandKey(cmd)
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
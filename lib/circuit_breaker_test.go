@@ -0,0 +1,76 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestCircuitBreakerPerKey(t *testing.T) {
+	cb := newCircuitBreaker(RedisAdditionalOptions{
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  50 * time.Millisecond,
+	})
+
+	var failing error = io.EOF
+	hook := cb.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error {
+		if cmd.Args()[1] == "bad" {
+			return failing
+		}
+		return nil
+	})
+	get := func(key string) error {
+		return hook(context.Background(), redis.NewStringCmd(context.Background(), "get", key))
+	}
+
+	for range 2 {
+		if err := get("bad"); !errors.Is(err, io.EOF) {
+			t.Fatalf("get bad: got %v, want io.EOF", err)
+		}
+	}
+	if err := get("bad"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("get bad after threshold: got %v, want ErrCircuitOpen", err)
+	}
+	if err := get("good"); err != nil {
+		t.Fatalf("get good while bad is open: %v", err)
+	}
+	if state := cb.getState(); state != CircuitOpen {
+		t.Fatalf("state: got %q, want %q", state, CircuitOpen)
+	}
+	if keys := cb.openKeys(); len(keys) != 1 || keys[0] != "bad" {
+		t.Fatalf("open keys: got %q, want [bad]", keys)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	failing = nil
+	if err := get("bad"); err != nil {
+		t.Fatalf("probe after cooldown: %v", err)
+	}
+	if state := cb.getState(); state != CircuitClosed {
+		t.Fatalf("state after probe: got %q, want %q", state, CircuitClosed)
+	}
+	if keys := cb.openKeys(); len(keys) != 0 {
+		t.Fatalf("open keys after probe: got %q, want none", keys)
+	}
+}
+
+func TestCircuitBreakerIgnoresCanceled(t *testing.T) {
+	cb := newCircuitBreaker(RedisAdditionalOptions{CircuitBreakerThreshold: 1})
+
+	for _, err := range []error{context.Canceled, redis.Nil} {
+		cb.record(context.Background(), "key", err)
+		if state := cb.getState(); state != CircuitClosed {
+			t.Fatalf("after %v: state %q, want %q", err, state, CircuitClosed)
+		}
+	}
+	if isConnectionFailure(context.Canceled) {
+		t.Fatal("context.Canceled counted as a connection failure")
+	}
+	if !isConnectionFailure(context.DeadlineExceeded) {
+		t.Fatal("context.DeadlineExceeded not counted as a connection failure")
+	}
+}
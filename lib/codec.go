@@ -0,0 +1,186 @@
+package redisgklib
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// compressedPrefix marks a compressed object: base64 of gzipped JSON follows
+// JSON text never starts with ~, so compressed and plain values can be told apart,
+// and base64 keeps values valid UTF-8 for export, key events and Lua scripts
+const compressedPrefix = "~gz:"
+
+// marshalValue serializes value to JSON according to instance options
+func (v *RedisGk) marshalValue(value any) ([]byte, error) {
+	if !v.disableHTMLEscape && v.jsonIndent == "" {
+		return json.Marshal(value)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(!v.disableHTMLEscape)
+	if v.jsonIndent != "" {
+		enc.SetIndent("", v.jsonIndent)
+	}
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+
+	// Encoder always terminates the value with a newline
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// compressValue compresses serialized data over CompressionThreshold, or any data
+// in a WithCompression(true) view. Data is kept as is if compression doesn't make it smaller
+func (v *RedisGk) compressValue(data []byte) ([]byte, error) {
+	if !v.forceCompression && (v.compressionThreshold <= 0 || len(data) <= v.compressionThreshold) {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(compressedPrefix)
+	b64 := base64.NewEncoder(base64.StdEncoding, &buf)
+	gz := gzip.NewWriter(b64)
+	if _, err := gz.Write(data); err != nil {
+		return nil, fmt.Errorf("compression error: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("compression error: %w", err)
+	}
+	if err := b64.Close(); err != nil {
+		return nil, fmt.Errorf("compression error: %w", err)
+	}
+
+	if buf.Len() >= len(data) {
+		return data, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressValue returns serialized data of a value written by compressValue
+// Plain values are returned as is, so reads work whatever CompressionThreshold is
+func decompressValue(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(compressedPrefix)) {
+		return data, nil
+	}
+
+	b64 := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data[len(compressedPrefix):]))
+	gz, err := gzip.NewReader(b64)
+	if err != nil {
+		return nil, fmt.Errorf("decompression error: %w", err)
+	}
+	defer gz.Close()
+
+	plain, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, fmt.Errorf("decompression error: %w", err)
+	}
+	return plain, nil
+}
+
+// rawPrefix escapes SetBytes data that itself starts with a reserved prefix
+// (~gz:, ~enc: or ~raw:), so it isn't mistaken for a compressed or encrypted value on read
+const rawPrefix = "~raw:"
+
+// escapeRawValue prepends rawPrefix to binary data starting with a reserved prefix
+func escapeRawValue(data []byte) []byte {
+	for _, prefix := range []string{compressedPrefix, encryptedPrefix, rawPrefix} {
+		if bytes.HasPrefix(data, []byte(prefix)) {
+			return append([]byte(rawPrefix), data...)
+		}
+	}
+	return data
+}
+
+// unescapeRawValue removes rawPrefix added by escapeRawValue
+func unescapeRawValue(data []byte) []byte {
+	return bytes.TrimPrefix(data, []byte(rawPrefix))
+}
+
+// typeTagPrefix starts the envelope of a value stored with TypeTags:
+// {"__redisgk_type":"<type name>","value":<JSON of the value>}
+const typeTagPrefix = `{"__redisgk_type":`
+
+// typeName returns the name of the value type used in type tags, e.g. main.User
+// Pointers are dereferenced, so *User and User share the name
+func typeName(value any) string {
+	return strings.TrimLeft(fmt.Sprintf("%T", value), "*")
+}
+
+// tagValue wraps serialized data into a type envelope if TypeTags is enabled
+// The envelope is assembled by hand to keep HTML escaping and indentation of data
+func (v *RedisGk) tagValue(value any, data []byte) ([]byte, error) {
+	if !v.typeTags {
+		return data, nil
+	}
+
+	name, err := json.Marshal(typeName(value))
+	if err != nil {
+		return nil, err
+	}
+
+	tagged := make([]byte, 0, len(typeTagPrefix)+len(name)+len(data)+10)
+	tagged = append(tagged, typeTagPrefix...)
+	tagged = append(tagged, name...)
+	tagged = append(tagged, `,"value":`...)
+	tagged = append(tagged, data...)
+	tagged = append(tagged, '}')
+	return tagged, nil
+}
+
+// untagValue returns data of a type envelope after checking the stored type name
+// against dst; data without an envelope is returned as is
+func untagValue(data []byte, dst any) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(typeTagPrefix)) {
+		return data, nil
+	}
+
+	var envelope struct {
+		Type  string          `json:"__redisgk_type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	if want := typeName(dst); envelope.Type != want {
+		return nil, fmt.Errorf("%w: stored %s, requested %s", ErrTypeMismatch, envelope.Type, want)
+	}
+	return envelope.Value, nil
+}
+
+// encodeValue serializes value, tags, compresses and encrypts it according to instance options
+func (v *RedisGk) encodeValue(value any) ([]byte, error) {
+	data, err := v.marshalValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return v.encodeMarshaled(value, data)
+}
+
+// encodeMarshaled tags, compresses and encrypts data serialized by marshalValue
+func (v *RedisGk) encodeMarshaled(value any, data []byte) ([]byte, error) {
+	tagged, err := v.tagValue(value, data)
+	if err != nil {
+		return nil, err
+	}
+	return v.sealValue(tagged)
+}
+
+// unmarshalValue deserializes stored data into dst, decrypting and decompressing it
+// and checking its type tag if needed
+func (v *RedisGk) unmarshalValue(data []byte, dst any) error {
+	plain, err := v.openValue(data)
+	if err != nil {
+		return err
+	}
+	plain, err = untagValue(plain, dst)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plain, dst)
+}
@@ -0,0 +1,155 @@
+package redisgklib
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type htmlValue struct {
+	Query string `json:"query"`
+}
+
+func TestDisableHTMLEscape(t *testing.T) {
+	value := htmlValue{Query: "a=1&b=<2>"}
+
+	escaped, fake := newFakeRedisGk(t)
+	if err := SetObj(escaped, []string{"doc"}, value); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := fake.get("doc")
+	if raw != `{"query":"a=1\u0026b=\u003c2\u003e"}` {
+		t.Errorf("default encoding stored %s, want HTML characters escaped", raw)
+	}
+
+	plain, fake := newFakeRedisGk(t, RedisAdditionalOptions{DisableHTMLEscape: true})
+	if err := SetObj(plain, []string{"doc"}, value); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ = fake.get("doc")
+	if raw != `{"query":"a=1&b=<2>"}` {
+		t.Errorf("with DisableHTMLEscape stored %s, want the raw characters", raw)
+	}
+
+	got, err := GetObj[htmlValue](plain, []string{"doc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != value {
+		t.Errorf("read back %+v, want %+v", *got, value)
+	}
+
+	indented, fake := newFakeRedisGk(t, RedisAdditionalOptions{JSONIndent: "  "})
+	if err := SetObj(indented, []string{"doc"}, value); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ = fake.get("doc")
+	if raw != "{\n  \"query\": \"a=1\\u0026b=\\u003c2\\u003e\"\n}" {
+		t.Errorf("with JSONIndent stored %q, want indented JSON without a trailing newline", raw)
+	}
+}
+
+type invoice struct {
+	Number string `json:"number"`
+}
+
+type receipt struct {
+	Number string `json:"number"`
+}
+
+func TestTypeTagsMismatch(t *testing.T) {
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{TypeTags: true})
+
+	if err := SetObj(v, []string{"docs", "1"}, invoice{Number: "A-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if raw, _ := fake.get("docs:1"); !strings.HasPrefix(raw, typeTagPrefix) {
+		t.Errorf("stored %s, want a type envelope", raw)
+	}
+
+	// Same JSON shape, different type
+	if _, err := GetObj[receipt](v, []string{"docs", "1"}); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("GetObj of another type: got %v, want ErrTypeMismatch", err)
+	}
+	got, err := GetObj[invoice](v, []string{"docs", "1"})
+	if err != nil || got.Number != "A-1" {
+		t.Errorf("GetObj of the stored type: got %+v, %v", got, err)
+	}
+
+	// Objects written without TypeTags are read as is
+	fake.data["docs:2"] = `{"number":"B-2"}`
+	if got, err := GetObj[receipt](v, []string{"docs", "2"}); err != nil || got.Number != "B-2" {
+		t.Errorf("GetObj of an untagged object: got %+v, %v", got, err)
+	}
+}
+
+func TestWithCompressionOverride(t *testing.T) {
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{CompressionThreshold: 256})
+
+	type page struct {
+		Body string `json:"body"`
+	}
+	value := page{Body: strings.Repeat("<p>same paragraph</p>", 20)}
+
+	// Same value, compression forced off and on
+	if err := SetObj(v.WithCompression(false), []string{"plain"}, value); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetObj(v.WithCompression(true), []string{"packed"}, value); err != nil {
+		t.Fatal(err)
+	}
+	if raw, _ := fake.get("plain"); strings.HasPrefix(raw, compressedPrefix) {
+		t.Errorf("WithCompression(false) stored a compressed value %.40q", raw)
+	}
+	if raw, _ := fake.get("packed"); !strings.HasPrefix(raw, compressedPrefix) {
+		t.Errorf("WithCompression(true) stored an uncompressed value %.40q", raw)
+	}
+
+	// Reads detect compression regardless of the view
+	for _, key := range []string{"plain", "packed"} {
+		got, err := GetObj[page](v.WithCompression(false), []string{key})
+		if err != nil || *got != value {
+			t.Errorf("GetObj(%s): got %.40v, %v", key, got, err)
+		}
+	}
+
+	// Data below the threshold is compressed only when forced
+	data := bytes.Repeat([]byte("ab"), 64)
+	if err := v.WithCompression(true).SetBytes([]string{"bytes"}, data); err != nil {
+		t.Fatal(err)
+	}
+	if raw, _ := fake.get("bytes"); !strings.HasPrefix(raw, compressedPrefix) {
+		t.Errorf("SetBytes with WithCompression(true) stored %q", raw)
+	}
+	if got, err := v.GetBytes([]string{"bytes"}); err != nil || !bytes.Equal(got, data) {
+		t.Errorf("GetBytes: got %q, %v", got, err)
+	}
+}
+
+func TestSetBytesReservedPrefix(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	encryptor, err := NewAESGCMEncryptor(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, _ := newFakeRedisGk(t)
+	encrypted, _ := newFakeRedisGk(t, RedisAdditionalOptions{Encryptor: encryptor})
+
+	values := [][]byte{
+		[]byte(compressedPrefix + "not base64"),
+		[]byte(encryptedPrefix + "not base64"),
+		[]byte(rawPrefix + "data"),
+		[]byte("~other"),
+	}
+	for _, v := range []*RedisGk{plain, plain.WithCompression(true), encrypted} {
+		for _, value := range values {
+			if err := v.SetBytes([]string{"bytes"}, value); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := v.GetBytes([]string{"bytes"}); err != nil || !bytes.Equal(got, value) {
+				t.Errorf("GetBytes of %q: got %q, %v", value, got, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package redisgklib
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+)
+
+// ConfigError - invalid setting reported by ConfigBuilder
+type ConfigError struct {
+	Field string // Name of the setting, e.g. Port
+	Err   error
+}
+
+// Error returns the error message with the setting name
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigBuilder - fluent builder of RedisConfConn
+// Settings are validated as they are set; after the first invalid one the other
+// calls do nothing and Build returns its *ConfigError.
+type ConfigBuilder struct {
+	conf RedisConfConn
+	err  error
+}
+
+// NewConfig starts a configuration for the Redis server at host:port
+func NewConfig(host string, port int) *ConfigBuilder {
+	b := &ConfigBuilder{conf: RedisConfConn{Host: host, Port: port}}
+
+	switch {
+	case host == "":
+		b.fail("Host", errors.New("host is required"))
+	case !isValidHost(host):
+		b.fail("Host", fmt.Errorf("not an IP address or domain name: %s", host))
+	case port < 1 || port > 65535:
+		b.fail("Port", fmt.Errorf("must be in range 1-65535, got: %d", port))
+	case port < 1024:
+		b.fail("Port", fmt.Errorf("must be >= 1024 (privileged ports require additional permissions), got: %d", port))
+	}
+
+	return b
+}
+
+// fail records the first invalid setting
+func (b *ConfigBuilder) fail(field string, err error) {
+	if b.err == nil {
+		b.err = &ConfigError{Field: field, Err: err}
+	}
+}
+
+// WithAdditionalOptions replaces all additional options
+// Call it before other With... methods, which change single options
+func (b *ConfigBuilder) WithAdditionalOptions(opts RedisAdditionalOptions) *ConfigBuilder {
+	if b.err == nil {
+		b.conf.AdditionalOptions = opts
+	}
+	return b
+}
+
+// WithUser sets the ACL user name
+func (b *ConfigBuilder) WithUser(user string) *ConfigBuilder {
+	if b.err == nil {
+		b.conf.User = user
+	}
+	return b
+}
+
+// WithPassword sets the password
+func (b *ConfigBuilder) WithPassword(password string) *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+	if password == "" {
+		b.fail("Password", errors.New("password is empty"))
+		return b
+	}
+	b.conf.Password = password
+	return b
+}
+
+// WithCredentialsProvider sets the provider asked for credentials on every new connection
+func (b *ConfigBuilder) WithCredentialsProvider(provider func() (user, password string, err error)) *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+	if provider == nil {
+		b.fail("CredentialsProvider", errors.New("provider is nil"))
+		return b
+	}
+	b.conf.CredentialsProvider = provider
+	return b
+}
+
+// WithDB sets the database index
+func (b *ConfigBuilder) WithDB(db int) *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+	if db < 0 {
+		b.fail("DB", fmt.Errorf("must be >= 0, got: %d", db))
+		return b
+	}
+	b.conf.DB = db
+	return b
+}
+
+// WithPoolSize sets the connection pool size
+func (b *ConfigBuilder) WithPoolSize(size int) *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+	if size <= 0 {
+		b.fail("PoolSize", fmt.Errorf("must be > 0, got: %d", size))
+		return b
+	}
+	b.conf.AdditionalOptions.PoolSize = size
+	return b
+}
+
+// WithTLS enables TLS with the given configuration
+func (b *ConfigBuilder) WithTLS(cfg *tls.Config) *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+	if cfg == nil {
+		b.fail("TLSConfig", errors.New("config is nil"))
+		return b
+	}
+	b.conf.AdditionalOptions.TLSConfig = cfg
+	return b
+}
+
+// Build returns the configuration, or the *ConfigError of the first invalid setting
+// The whole configuration is checked again, e.g. that a password or credentials provider is set
+func (b *ConfigBuilder) Build() (RedisConfConn, error) {
+	if b.err != nil {
+		return RedisConfConn{}, b.err
+	}
+	if err := validateRedisConfConn(b.conf); err != nil {
+		return RedisConfConn{}, &ConfigError{Field: "config", Err: err}
+	}
+	return b.conf, nil
+}
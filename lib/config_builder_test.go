@@ -0,0 +1,40 @@
+package redisgklib
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfigBuilderRejectsInvalidPort(t *testing.T) {
+	for _, port := range []int{0, -1, 80, 65536} {
+		_, err := NewConfig("localhost", port).WithPassword("secret").WithDB(1).Build()
+
+		var confErr *ConfigError
+		if !errors.As(err, &confErr) {
+			t.Fatalf("port %d: got %v, want *ConfigError", port, err)
+		}
+		if confErr.Field != "Port" {
+			t.Errorf("port %d: error field %q, want Port", port, confErr.Field)
+		}
+	}
+}
+
+func TestConfigBuilderKeepsFirstError(t *testing.T) {
+	_, err := NewConfig("localhost", 6379).WithDB(-1).WithPoolSize(0).Build()
+
+	var confErr *ConfigError
+	if !errors.As(err, &confErr) || confErr.Field != "DB" {
+		t.Fatalf("got %v, want *ConfigError for DB", err)
+	}
+}
+
+func TestConfigBuilderBuild(t *testing.T) {
+	conf, err := NewConfig("localhost", 6379).WithPassword("secret").WithDB(2).WithPoolSize(20).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Host != "localhost" || conf.Port != 6379 || conf.Password != "secret" || conf.DB != 2 ||
+		conf.AdditionalOptions.PoolSize != 20 {
+		t.Fatalf("unexpected config: %+v", conf)
+	}
+}
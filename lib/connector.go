@@ -3,16 +3,39 @@ package redisgklib
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// credentialsStore - credentials used for new connections, replaceable at runtime
+type credentialsStore struct {
+	mu       sync.RWMutex
+	user     string
+	password string
+}
+
+// get returns current credentials, used as go-redis CredentialsProvider
+func (cs *credentialsStore) get() (string, string) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.user, cs.password
+}
+
+// set replaces credentials for new connections
+func (cs *credentialsStore) set(user, password string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.user = user
+	cs.password = password
+}
+
 // newRedisClientConnector creates a new Redis client
-func newRedisClientConnector(conf RedisConfConn) (*redis.Client, error) {
+func newRedisClientConnector(conf RedisConfConn) (*redis.Client, *credentialsStore, error) {
 	// Check for empty configuration
-	if (RedisConfConn{}) == conf {
-		return nil, fmt.Errorf("configuration is empty")
+	if isEmptyConfig(conf) {
+		return nil, nil, fmt.Errorf("configuration is empty")
 	}
 
 	redisHost := conf.Host
@@ -23,26 +46,40 @@ func newRedisClientConnector(conf RedisConfConn) (*redis.Client, error) {
 	redisNDb := max(conf.DB, 0)
 
 	if err := validateRedisConfConn(conf); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	opts := &redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", redisHost, redisPort),
-		Username: redisUser,
-		Password: redisPassword,
-		DB:       redisNDb,
+		Addr: fmt.Sprintf("%s:%d", redisHost, redisPort),
+		DB:   redisNDb,
+	}
+
+	// Static credentials can be rotated with UpdateCredentials,
+	// a user provider is asked on every new connection instead
+	var creds *credentialsStore
+	if conf.CredentialsProvider != nil {
+		provider := conf.CredentialsProvider
+		opts.CredentialsProviderContext = func(ctx context.Context) (string, string, error) {
+			return provider()
+		}
+	} else {
+		creds = &credentialsStore{user: redisUser, password: redisPassword}
+		opts.CredentialsProvider = creds.get
 	}
 
 	opts = setRedisAdditionalOptions(opts, conf.AdditionalOptions)
 
 	redisClient := redis.NewClient(opts)
 
-	// Check Redis connection
-	if err := testRedisConnection(redisClient); err != nil {
-		return nil, fmt.Errorf("error: Redis connection error: %w", err)
+	// Check Redis connection, retrying while Redis is starting up
+	if !conf.AdditionalOptions.SkipServerSetup {
+		if err := testRedisConnectionWithRetry(redisClient, conf.AdditionalOptions); err != nil {
+			redisClient.Close()
+			return nil, nil, fmt.Errorf("error: Redis connection error: %w", err)
+		}
 	}
 
-	return redisClient, nil
+	return redisClient, creds, nil
 }
 
 // testRedisConnection checks Redis connection
@@ -62,6 +99,28 @@ func testRedisConnection(client *redis.Client) error {
 	return nil
 }
 
+// testRedisConnectionWithRetry checks Redis connection up to 1+StartupRetries times,
+// doubling StartupRetryDelay after every failed attempt
+func testRedisConnectionWithRetry(client *redis.Client, opts RedisAdditionalOptions) error {
+	delay := opts.StartupRetryDelay
+	if delay <= 0 {
+		delay = time.Second
+	}
+	const maxDelay = 30 * time.Second
+
+	err := testRedisConnection(client)
+	for attempt := 1; err != nil && attempt <= opts.StartupRetries; attempt++ {
+		logf(opts.Logger, context.Background(), "redisgk: connection attempt %d of %d failed, retrying in %s: %v",
+			attempt, opts.StartupRetries+1, delay, err)
+		time.Sleep(delay)
+		delay = min(delay*2, maxDelay)
+
+		err = testRedisConnection(client)
+	}
+
+	return err
+}
+
 // setRedisAdditionalOptions sets additional options for Redis client
 func setRedisAdditionalOptions(opts *redis.Options, additionalOptions RedisAdditionalOptions) *redis.Options {
 	if opts == nil {
@@ -95,6 +154,8 @@ func setRedisAdditionalOptions(opts *redis.Options, additionalOptions RedisAddit
 	opts.WriteTimeout = defaultWriteTimeout
 	opts.PoolSize = defaultPoolSize
 	opts.PoolTimeout = defaultPoolTimeout
+	opts.ContextTimeoutEnabled = additionalOptions.ContextTimeouts
+	opts.TLSConfig = additionalOptions.TLSConfig
 
 	return opts
 }
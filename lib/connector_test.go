@@ -0,0 +1,106 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// newStartingRedis returns a client whose first failures pings fail like a Redis
+// server that is not up yet, and the counter of pings
+func newStartingRedis(failures int) (*redis.Client, *int) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:6379"})
+	pings := 0
+	fake := &fakeRedis{data: make(map[string]string)}
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if cmd.Name() != "ping" {
+			return false, nil
+		}
+		pings++
+		if pings <= failures {
+			return true, errors.New("dial tcp 127.0.0.1:6379: connect: connection refused")
+		}
+		return false, nil
+	}
+	client.AddHook(fake)
+	return client, &pings
+}
+
+func TestStartupRetries(t *testing.T) {
+	client, pings := newStartingRedis(2)
+	defer client.Close()
+
+	logger := &testLogger{}
+	err := testRedisConnectionWithRetry(client, RedisAdditionalOptions{
+		StartupRetries:    3,
+		StartupRetryDelay: time.Millisecond,
+		Logger:            logger,
+	})
+	if err != nil {
+		t.Fatalf("connection check failed after retries: %v", err)
+	}
+	if *pings != 3 {
+		t.Errorf("%d pings, want 3", *pings)
+	}
+	if len(logger.messages) != 2 {
+		t.Errorf("%d retries logged, want 2", len(logger.messages))
+	}
+}
+
+func TestStartupRetriesExhausted(t *testing.T) {
+	client, pings := newStartingRedis(2)
+	defer client.Close()
+
+	err := testRedisConnectionWithRetry(client, RedisAdditionalOptions{
+		StartupRetries:    1,
+		StartupRetryDelay: time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("connection check succeeded with fewer retries than failures")
+	}
+	if *pings != 2 {
+		t.Errorf("%d pings, want 2", *pings)
+	}
+}
+
+func TestNewRedisGkWaitsForStartingServer(t *testing.T) {
+	// Reserve a port and start the server on it only after NewRedisGk was called
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	started := make(chan error, 1)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ln, err := net.Listen("tcp", addr)
+		if err == nil {
+			serveFake(t, ln, "secret")
+		}
+		started <- err
+	}()
+
+	v, err := NewRedisGk(RedisConfConn{
+		Host:     "127.0.0.1",
+		Port:     port,
+		Password: "secret",
+		AdditionalOptions: RedisAdditionalOptions{
+			StartupRetries:    5,
+			StartupRetryDelay: 50 * time.Millisecond,
+		},
+	})
+	if err := <-started; err != nil {
+		t.Skipf("reserved port was taken: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("NewRedisGk didn't wait for the server: %v", err)
+	}
+	v.Close()
+}
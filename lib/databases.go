@@ -0,0 +1,111 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// dbClients - clients of other databases opened by WithDB, shared with views
+// and closed together with the instance
+type dbClients struct {
+	main  *redis.Client
+	hooks []redis.Hook
+
+	mu      sync.Mutex
+	clients map[int]*redis.Client
+	closed  bool
+}
+
+// newDBClients creates a new pool opening clients with options of main
+// hooks are added to every opened client
+func newDBClients(main *redis.Client, hooks []redis.Hook) *dbClients {
+	return &dbClients{main: main, hooks: hooks, clients: make(map[int]*redis.Client)}
+}
+
+// get returns the client of the database, opening it on first use
+// A new client is checked with PING, so an out of range index fails here
+func (p *dbClients) get(ctx context.Context, db int) (*redis.Client, error) {
+	base := p.main.Options()
+	if base.DB == db {
+		return p.main, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, fmt.Errorf("redis instance is closed")
+	}
+	if client, ok := p.clients[db]; ok {
+		return client, nil
+	}
+
+	opts := *base
+	opts.DB = db
+	client := redis.NewClient(&opts)
+	for _, hook := range p.hooks {
+		client.AddHook(hook)
+	}
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error selecting database %d: %w", db, err)
+	}
+
+	p.clients[db] = client
+	return client, nil
+}
+
+// close closes all opened clients
+func (p *dbClients) close() error {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	var errs []error
+	for db, client := range p.clients {
+		if err := client.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("error closing database %d client: %w", db, err))
+		}
+	}
+	p.clients = nil
+	return errors.Join(errs...)
+}
+
+// WithDB returns a view of the instance whose operations target database db
+// The view uses a separate connection pool for db, opened on first use and reused
+// by later calls; the pools are closed with the instance. The connection uses the
+// credentials of the instance, also when called on an AsUser view. Key events keep
+// coming from the database the instance was configured with, unless listed in EventDBs.
+func (v *RedisGk) WithDB(db int) (*RedisGk, error) {
+	if v == nil || v.redisClient == nil || v.dbClients == nil {
+		return nil, fmt.Errorf("RedisGk instance or client is nil")
+	}
+
+	if db < 0 {
+		return nil, fmt.Errorf("db must be >= 0, got: %d", db)
+	}
+	if v.shards != nil {
+		return nil, fmt.Errorf("WithDB is not supported by a sharded instance")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	client, err := v.dbClients.get(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	view := v.view()
+	view.redisClient = client
+	return view, nil
+}
@@ -0,0 +1,44 @@
+package redisgklib
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithDB(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "config")
+
+	if err := v.SetString(key, "db0"); err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := v.WithDB(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.GetString(key); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetString on the DB 1 view: got %v, want ErrKeyNotFound", err)
+	}
+
+	if err := other.SetString(key, "db1"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { other.Del(key) })
+	if got, err := v.GetString(key); err != nil || got != "db0" {
+		t.Errorf("GetString on DB 0 after a write to DB 1: got %q, %v, want db0", got, err)
+	}
+
+	// The pool of a database is reused
+	again, err := v.WithDB(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.redisClient != other.redisClient {
+		t.Error("second WithDB(1) opened a new pool")
+	}
+
+	if _, err := v.WithDB(-1); err == nil {
+		t.Error("WithDB(-1) succeeded")
+	}
+}
@@ -0,0 +1,120 @@
+package redisgklib
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// encryptedPrefix marks an encrypted value: base64 of the ciphertext follows
+// Like compressed values, encrypted ones stay valid UTF-8 for export, key events and Lua scripts
+const encryptedPrefix = "~enc:"
+
+// aesGCMEncryptor - Encryptor using AES-GCM with a random nonce stored before the ciphertext
+type aesGCMEncryptor struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMEncryptor creates an AES-GCM Encryptor, key must be 16, 24 or 32 bytes
+// (AES-128, AES-192 or AES-256)
+func NewAESGCMEncryptor(key []byte) (Encryptor, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AES key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AES-GCM cipher: %w", err)
+	}
+	return &aesGCMEncryptor{aead: aead}, nil
+}
+
+// Encrypt seals plaintext, the result is the nonce followed by the ciphertext
+func (e *aesGCMEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, e.aead.NonceSize(), e.aead.NonceSize()+len(plaintext)+e.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+	return e.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens data produced by Encrypt
+func (e *aesGCMEncryptor) Decrypt(data []byte) ([]byte, error) {
+	if len(data) < e.aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	nonce, ciphertext := data[:e.aead.NonceSize()], data[e.aead.NonceSize():]
+	return e.aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// plainView returns a view of the instance storing values without encryption, for
+// numbers that must stay readable by INCR, INCRBYFLOAT and Lua scripts
+func (v *RedisGk) plainView() *RedisGk {
+	if v == nil || v.encryptor == nil {
+		return v
+	}
+
+	view := v.view()
+	view.encryptor = nil
+	return view
+}
+
+// encryptValue encrypts stored data with the configured Encryptor, if any
+func (v *RedisGk) encryptValue(data []byte) ([]byte, error) {
+	if v.encryptor == nil {
+		return data, nil
+	}
+
+	ciphertext, err := v.encryptor.Encrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("encryption error: %w", err)
+	}
+
+	out := make([]byte, len(encryptedPrefix)+base64.StdEncoding.EncodedLen(len(ciphertext)))
+	copy(out, encryptedPrefix)
+	base64.StdEncoding.Encode(out[len(encryptedPrefix):], ciphertext)
+	return out, nil
+}
+
+// decryptValue returns the data of a value written by encryptValue
+// Plain values are returned as is, so data written before encryption was enabled is still read
+func (v *RedisGk) decryptValue(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(encryptedPrefix)) {
+		return data, nil
+	}
+	if v.encryptor == nil {
+		return nil, fmt.Errorf("value is encrypted, but no Encryptor is configured")
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(string(data[len(encryptedPrefix):]))
+	if err != nil {
+		return nil, fmt.Errorf("decryption error: %w", err)
+	}
+	plain, err := v.encryptor.Decrypt(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decryption error: %w", err)
+	}
+	return plain, nil
+}
+
+// sealValue compresses and then encrypts data according to instance options
+// Compression comes first, encrypted data doesn't compress
+func (v *RedisGk) sealValue(data []byte) ([]byte, error) {
+	compressed, err := v.compressValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return v.encryptValue(compressed)
+}
+
+// openValue decrypts and decompresses data written by sealValue
+func (v *RedisGk) openValue(data []byte) ([]byte, error) {
+	plain, err := v.decryptValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return decompressValue(plain)
+}
@@ -0,0 +1,98 @@
+package redisgklib
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncryptionAtRest(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	encryptor, err := NewAESGCMEncryptor(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{Encryptor: encryptor, MaxValueSize: 128})
+
+	type patient struct {
+		Name string `json:"name"`
+		SSN  string `json:"ssn"`
+	}
+	in := patient{Name: "Alice", SSN: "123-45-6789"}
+	if err := SetObj(v, []string{"patients", "1"}, in); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString([]string{"notes", "1"}, "allergic to penicillin"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, plaintext := range map[string]string{"patients:1": in.SSN, "notes:1": "penicillin"} {
+		raw, _ := fake.get(name)
+		if !strings.HasPrefix(raw, encryptedPrefix) || strings.Contains(raw, plaintext) {
+			t.Errorf("%s stored as %q, want ciphertext", name, raw)
+		}
+	}
+
+	got, err := GetObj[patient](v, []string{"patients", "1"})
+	if err != nil || *got != in {
+		t.Errorf("GetObj: got %+v, %v, want %+v", got, err, in)
+	}
+	if note, err := v.GetString([]string{"notes", "1"}); err != nil || note != "allergic to penicillin" {
+		t.Errorf("GetString: got %q, %v", note, err)
+	}
+
+	// 80 bytes fit MaxValueSize, their ciphertext doesn't
+	if err := v.SetString([]string{"notes", "2"}, strings.Repeat("x", 80)); err == nil {
+		t.Error("SetString with ciphertext over MaxValueSize succeeded")
+	}
+
+	// A different key can't read the values
+	otherKey, err := NewAESGCMEncryptor(bytes.Repeat([]byte{8}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, otherFake := newFakeRedisGk(t, RedisAdditionalOptions{Encryptor: otherKey})
+	otherFake.data["patients:1"], _ = fake.get("patients:1")
+	if _, err := GetObj[patient](other, []string{"patients", "1"}); err == nil {
+		t.Error("GetObj with a different key succeeded")
+	}
+
+	if _, err := NewAESGCMEncryptor([]byte("short")); err == nil {
+		t.Error("NewAESGCMEncryptor with a 5-byte key succeeded")
+	}
+}
+
+func TestEncryptionKeepsNumbersPlain(t *testing.T) {
+	encryptor, err := NewAESGCMEncryptor(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{Encryptor: encryptor})
+
+	if err := v.SetInt([]string{"counter"}, 42); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetFloat([]string{"ratio"}, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetBool([]string{"flag"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stored as INCR and INCRBYFLOAT expect them
+	for key, want := range map[string]string{"counter": "42", "ratio": "0.5"} {
+		if raw, _ := fake.get(key); raw != want {
+			t.Errorf("%s stored as %q, want %q", key, raw, want)
+		}
+	}
+	if raw, _ := fake.get("flag"); !strings.HasPrefix(raw, encryptedPrefix) {
+		t.Errorf("flag stored as %q, want ciphertext", raw)
+	}
+
+	if n, err := v.GetInt64([]string{"counter"}); err != nil || n != 42 {
+		t.Errorf("GetInt64: got %d, %v", n, err)
+	}
+	if f, err := v.GetFloat64([]string{"ratio"}); err != nil || f != 0.5 {
+		t.Errorf("GetFloat64: got %v, %v", f, err)
+	}
+}
@@ -0,0 +1,48 @@
+package redisgklib
+
+import "errors"
+
+var (
+	// ErrKeyNotFound - key is not present in Redis
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyExists - target key is already present in Redis
+	ErrKeyExists = errors.New("key already exists")
+	// ErrNoMatch - pattern search found nothing (only with NoMatchError)
+	ErrNoMatch = errors.New("no keys match the pattern")
+	// ErrElementNotFound - element is not present in the collection
+	ErrElementNotFound = errors.New("element not found")
+	// ErrListsEmpty - all lists passed to a multi-key pop are empty
+	ErrListsEmpty = errors.New("all lists are empty")
+	// ErrInvalidValue - stored value can't be converted to the requested type
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrTypeMismatch - stored object was tagged with another type than the requested one
+	ErrTypeMismatch = errors.New("stored type mismatch")
+	// ErrQuotaExceeded - write would exceed the key limit of a prefix
+	ErrQuotaExceeded = errors.New("prefix quota exceeded")
+	// ErrConnection - Redis could not be reached or the connection broke; worth a retry
+	ErrConnection = errors.New("redis connection error")
+	// ErrTimeout - command didn't complete in time (deadline, pool or network timeout); worth a retry
+	ErrTimeout = errors.New("redis timeout")
+	// ErrWrongType - command was run against a key holding another type (WRONGTYPE reply)
+	ErrWrongType = errors.New("wrong key type")
+	// ErrServer - Redis rejected the command with an error reply; retrying won't help
+	ErrServer = errors.New("redis server error")
+	// ErrOutOfMemory - Redis rejected a write because maxmemory is reached (OOM reply)
+	ErrOutOfMemory = errors.New("redis out of memory")
+	// ErrClusterRedirect - server replied with a MOVED/ASK redirection, it is a Redis Cluster node
+	ErrClusterRedirect = errors.New("cluster redirect, the server is a Redis Cluster node and needs a cluster client")
+	// ErrPolicyNotSupported - metric is not tracked under the current maxmemory-policy
+	ErrPolicyNotSupported = errors.New("not supported by maxmemory-policy")
+	// ErrModuleNotLoaded - command of a Redis module that the server doesn't have
+	ErrModuleNotLoaded = errors.New("redis module not loaded")
+	// ErrLockNotAcquired - lock is held by someone else
+	ErrLockNotAcquired = errors.New("lock not acquired")
+	// ErrLockNotHeld - lock has expired, was taken over or already released
+	ErrLockNotHeld = errors.New("lock not held")
+	// ErrCircuitOpen - command rejected without contacting Redis after repeated failures
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+	// ErrCrossShard - keys of one command or transaction are on different nodes of a sharded instance
+	ErrCrossShard = errors.New("keys are on different shards")
+	// ErrCloseTimeout - background goroutines didn't exit before the close timeout
+	ErrCloseTimeout = errors.New("background goroutines did not exit")
+)
@@ -0,0 +1,382 @@
+package redisgklib
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// exportFormatVersion - version of the document produced by ExportPrefix
+const exportFormatVersion = 1
+
+// ExportDocument - keys under a prefix with their values and TTLs
+type ExportDocument struct {
+	Version int           `json:"version"`
+	Keys    []ExportedKey `json:"keys"`
+}
+
+// exportEncodingBase64 - Encoding of keys whose strings are base64 in the document
+const exportEncodingBase64 = "base64"
+
+// ExportedKey - one key of an export document
+// Value depends on Type: string for strings, array of strings for lists and sets,
+// object for hashes and array of ExportedZMember for sorted sets.
+// If any string of the value is not valid UTF-8, Encoding is "base64" and all strings
+// of the value (members, hash fields and values) are base64-encoded
+type ExportedKey struct {
+	Key      string          `json:"key"`
+	Type     string          `json:"type"`
+	TTLMs    int64           `json:"ttl_ms"` // Remaining TTL in milliseconds (0 - no expiration)
+	Encoding string          `json:"encoding,omitempty"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// ExportedZMember - sorted set member of an export document
+type ExportedZMember struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// ExportPrefix dumps all keys under the pattern with values and TTLs into a JSON document
+// Supported types are strings, lists, sets, sorted sets and hashes
+func (v *RedisGk) ExportPrefix(patternPath []string) ([]byte, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	pattern, err := v.prefixPatternConvertor(patternPath)
+	if err != nil {
+		return nil, fmt.Errorf("pattern conversion error: %w", err)
+	}
+
+	doc := ExportDocument{Version: exportFormatVersion, Keys: []ExportedKey{}}
+	var cursor uint64
+
+	for {
+		var keys []string
+		keys, cursor, err = v.scanKeysPage(ctx, pattern, cursor, 100, "")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			exported, found, err := v.exportKey(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				doc.Keys = append(doc.Keys, exported)
+			}
+		}
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("export serialization error: %w", err)
+	}
+
+	return data, nil
+}
+
+// exportKey reads type, value and TTL of one key
+// Returns false if the key disappeared during export
+func (v *RedisGk) exportKey(ctx context.Context, key string) (ExportedKey, bool, error) {
+	keyType, err := v.redisClient.Type(ctx, key).Result()
+	if err != nil {
+		return ExportedKey{}, false, fmt.Errorf("error getting type of key %s: %w", key, err)
+	}
+
+	var value any
+	switch keyType {
+	case "none":
+		return ExportedKey{}, false, nil
+	case KeyTypeString:
+		value, err = v.redisClient.Get(ctx, key).Result()
+	case KeyTypeList:
+		value, err = v.redisClient.LRange(ctx, key, 0, -1).Result()
+	case KeyTypeSet:
+		value, err = v.redisClient.SMembers(ctx, key).Result()
+	case KeyTypeHash:
+		value, err = v.redisClient.HGetAll(ctx, key).Result()
+	case KeyTypeZSet:
+		var members []redis.Z
+		members, err = v.redisClient.ZRangeWithScores(ctx, key, 0, -1).Result()
+		zMembers := make([]ExportedZMember, 0, len(members))
+		for _, m := range members {
+			zMembers = append(zMembers, ExportedZMember{Member: fmt.Sprint(m.Member), Score: m.Score})
+		}
+		value = zMembers
+	default:
+		return ExportedKey{}, false, fmt.Errorf("unsupported type %s of key %s", keyType, key)
+	}
+	if err != nil {
+		if err == redis.Nil {
+			return ExportedKey{}, false, nil
+		}
+		return ExportedKey{}, false, fmt.Errorf("error getting value of key %s: %w", key, err)
+	}
+
+	ttl, err := v.redisClient.PTTL(ctx, key).Result()
+	if err != nil {
+		return ExportedKey{}, false, fmt.Errorf("error getting TTL of key %s: %w", key, err)
+	}
+
+	// json.Marshal replaces invalid UTF-8 with U+FFFD, so binary values are base64-encoded
+	encoding := ""
+	if !exportValueIsUTF8(value) {
+		value = exportValueBase64(value)
+		encoding = exportEncodingBase64
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return ExportedKey{}, false, fmt.Errorf("value serialization error for key %s: %w", key, err)
+	}
+
+	return ExportedKey{
+		Key:      key,
+		Type:     keyType,
+		TTLMs:    max(ttl.Milliseconds(), 0),
+		Encoding: encoding,
+		Value:    raw,
+	}, true, nil
+}
+
+// exportValueIsUTF8 checks that every string of an exported value is valid UTF-8
+func exportValueIsUTF8(value any) bool {
+	switch val := value.(type) {
+	case string:
+		return utf8.ValidString(val)
+	case []string:
+		for _, s := range val {
+			if !utf8.ValidString(s) {
+				return false
+			}
+		}
+	case map[string]string:
+		for f, s := range val {
+			if !utf8.ValidString(f) || !utf8.ValidString(s) {
+				return false
+			}
+		}
+	case []ExportedZMember:
+		for _, m := range val {
+			if !utf8.ValidString(m.Member) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// exportValueBase64 returns a copy of an exported value with all strings base64-encoded
+func exportValueBase64(value any) any {
+	enc := base64.StdEncoding.EncodeToString
+	switch val := value.(type) {
+	case string:
+		return enc([]byte(val))
+	case []string:
+		encoded := make([]string, len(val))
+		for i, s := range val {
+			encoded[i] = enc([]byte(s))
+		}
+		return encoded
+	case map[string]string:
+		encoded := make(map[string]string, len(val))
+		for f, s := range val {
+			encoded[enc([]byte(f))] = enc([]byte(s))
+		}
+		return encoded
+	case []ExportedZMember:
+		encoded := make([]ExportedZMember, len(val))
+		for i, m := range val {
+			encoded[i] = ExportedZMember{Member: enc([]byte(m.Member)), Score: m.Score}
+		}
+		return encoded
+	}
+	return value
+}
+
+// decodeExported returns the original string of an exported value string
+func decodeExported(s, encoding string) (string, error) {
+	switch encoding {
+	case "":
+		return s, nil
+	case exportEncodingBase64:
+		data, err := base64.StdEncoding.DecodeString(s)
+		return string(data), err
+	default:
+		return "", fmt.Errorf("unsupported encoding %s", encoding)
+	}
+}
+
+// ImportPrefix restores keys from a document produced by ExportPrefix
+// Existing keys are replaced only if overwrite is true. TTLs are restored with PEXPIRE.
+// Returns the number of imported keys.
+func (v *RedisGk) ImportPrefix(data []byte, overwrite bool) (int, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	var doc ExportDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return 0, fmt.Errorf("import deserialization error: %w", err)
+	}
+	if doc.Version != exportFormatVersion {
+		return 0, fmt.Errorf("unsupported export format version: %d", doc.Version)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	imported := 0
+	for i, key := range doc.Keys {
+		if key.Key == "" {
+			return imported, fmt.Errorf("empty key at index %d", i)
+		}
+		if err := checkMaxSizeKey(key.Key); err != nil {
+			return imported, err
+		}
+
+		if !overwrite {
+			exists, err := v.redisClient.Exists(ctx, key.Key).Result()
+			if err != nil {
+				return imported, fmt.Errorf("error checking key existence: %w", err)
+			}
+			if exists > 0 {
+				continue
+			}
+		}
+
+		if err := v.importKey(ctx, key); err != nil {
+			return imported, err
+		}
+		imported++
+	}
+
+	return imported, nil
+}
+
+// importKey replaces one key with the exported value in a transaction
+func (v *RedisGk) importKey(ctx context.Context, key ExportedKey) error {
+	var write func(pipe redis.Pipeliner)
+
+	decodeAll := func(values []string) error {
+		for i, s := range values {
+			decoded, err := decodeExported(s, key.Encoding)
+			if err != nil {
+				return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+			}
+			values[i] = decoded
+		}
+		return nil
+	}
+
+	switch key.Type {
+	case KeyTypeString:
+		var value string
+		if err := json.Unmarshal(key.Value, &value); err != nil {
+			return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+		}
+		value, err := decodeExported(value, key.Encoding)
+		if err != nil {
+			return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+		}
+		write = func(pipe redis.Pipeliner) { pipe.Set(ctx, key.Key, value, 0) }
+	case KeyTypeList:
+		var values []string
+		if err := json.Unmarshal(key.Value, &values); err != nil {
+			return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+		}
+		if err := decodeAll(values); err != nil {
+			return err
+		}
+		write = func(pipe redis.Pipeliner) {
+			if len(values) > 0 {
+				pipe.RPush(ctx, key.Key, values)
+			}
+		}
+	case KeyTypeSet:
+		var members []string
+		if err := json.Unmarshal(key.Value, &members); err != nil {
+			return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+		}
+		if err := decodeAll(members); err != nil {
+			return err
+		}
+		write = func(pipe redis.Pipeliner) {
+			if len(members) > 0 {
+				pipe.SAdd(ctx, key.Key, members)
+			}
+		}
+	case KeyTypeHash:
+		var encoded map[string]string
+		if err := json.Unmarshal(key.Value, &encoded); err != nil {
+			return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+		}
+		fields := make(map[string]string, len(encoded))
+		for f, s := range encoded {
+			field, err := decodeExported(f, key.Encoding)
+			if err != nil {
+				return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+			}
+			if fields[field], err = decodeExported(s, key.Encoding); err != nil {
+				return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+			}
+		}
+		write = func(pipe redis.Pipeliner) {
+			if len(fields) > 0 {
+				pipe.HSet(ctx, key.Key, fields)
+			}
+		}
+	case KeyTypeZSet:
+		var members []ExportedZMember
+		if err := json.Unmarshal(key.Value, &members); err != nil {
+			return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+		}
+		for i := range members {
+			member, err := decodeExported(members[i].Member, key.Encoding)
+			if err != nil {
+				return fmt.Errorf("invalid value of key %s: %w", key.Key, err)
+			}
+			members[i].Member = member
+		}
+		write = func(pipe redis.Pipeliner) {
+			zs := make([]redis.Z, 0, len(members))
+			for _, m := range members {
+				zs = append(zs, redis.Z{Member: m.Member, Score: m.Score})
+			}
+			if len(zs) > 0 {
+				pipe.ZAdd(ctx, key.Key, zs...)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type %s of key %s", key.Type, key.Key)
+	}
+
+	_, err := v.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key.Key)
+		write(pipe)
+		if key.TTLMs > 0 {
+			pipe.PExpire(ctx, key.Key, time.Duration(key.TTLMs)*time.Millisecond)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error importing key %s: %w", key.Key, err)
+	}
+
+	return nil
+}
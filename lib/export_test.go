@@ -0,0 +1,135 @@
+package redisgklib
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	ctx := context.Background()
+	client := v.redisClient
+
+	str := testKeyName(t, v, prefix, "str")
+	list := testKeyName(t, v, prefix, "list")
+	set := testKeyName(t, v, prefix, "set")
+	zset := testKeyName(t, v, prefix, "zset")
+	hash := testKeyName(t, v, prefix, "hash")
+	binList := testKeyName(t, v, prefix, "binlist")
+	binHash := testKeyName(t, v, prefix, "binhash")
+
+	// Invalid UTF-8 must survive the JSON document
+	binary := []byte{0x00, 0xff, 0xfe, 'a', 0x80}
+
+	if err := v.SetString(testKey(prefix, "str"), "value", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	client.RPush(ctx, list, "a", "b", "a")
+	client.SAdd(ctx, set, "x", "y")
+	client.ZAdd(ctx, zset, redis.Z{Member: "m1", Score: 1.5}, redis.Z{Member: "m2", Score: -2})
+	client.HSet(ctx, hash, "f1", "v1", "f2", "v2")
+	if err := v.SetBytes(testKey(prefix, "bin"), binary); err != nil {
+		t.Fatal(err)
+	}
+	client.RPush(ctx, binList, "text", binary)
+	client.HSet(ctx, binHash, string(binary), binary, "f", "v")
+
+	data, err := v.ExportPrefix(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := v.DelByPattern(prefix); err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := v.ImportPrefix(data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported != 8 {
+		t.Errorf("ImportPrefix imported %d keys, want 8", imported)
+	}
+
+	if value, _ := client.Get(ctx, str).Result(); value != "value" {
+		t.Errorf("string %q, want %q", value, "value")
+	}
+	if ttl, _ := client.PTTL(ctx, str).Result(); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("string TTL %s not preserved", ttl)
+	}
+	if ttl, _ := client.PTTL(ctx, list).Result(); ttl != -1 {
+		t.Errorf("list without TTL got TTL %s", ttl)
+	}
+	if values, _ := client.LRange(ctx, list, 0, -1).Result(); !slices.Equal(values, []string{"a", "b", "a"}) {
+		t.Errorf("list %q", values)
+	}
+	members, _ := client.SMembers(ctx, set).Result()
+	slices.Sort(members)
+	if !slices.Equal(members, []string{"x", "y"}) {
+		t.Errorf("set %q", members)
+	}
+	scored, _ := client.ZRangeWithScores(ctx, zset, 0, -1).Result()
+	if want := []redis.Z{{Member: "m2", Score: -2}, {Member: "m1", Score: 1.5}}; !reflect.DeepEqual(scored, want) {
+		t.Errorf("sorted set %v, want %v", scored, want)
+	}
+	if fields, _ := client.HGetAll(ctx, hash).Result(); !reflect.DeepEqual(fields, map[string]string{"f1": "v1", "f2": "v2"}) {
+		t.Errorf("hash %v", fields)
+	}
+	if value, err := v.GetBytes(testKey(prefix, "bin")); err != nil || !bytes.Equal(value, binary) {
+		t.Errorf("binary string %x (%v), want %x", value, err, binary)
+	}
+	if values, _ := client.LRange(ctx, binList, 0, -1).Result(); !slices.Equal(values, []string{"text", string(binary)}) {
+		t.Errorf("binary list %q", values)
+	}
+	if fields, _ := client.HGetAll(ctx, binHash).Result(); !reflect.DeepEqual(fields, map[string]string{string(binary): string(binary), "f": "v"}) {
+		t.Errorf("binary hash %q", fields)
+	}
+
+	// Existing keys are kept without overwrite
+	if err := v.SetString(testKey(prefix, "str"), "changed", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.ImportPrefix(data, false); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := client.Get(ctx, str).Result(); value != "changed" {
+		t.Errorf("import without overwrite replaced the string with %q", value)
+	}
+}
+
+func TestExportValueBase64(t *testing.T) {
+	binary := string([]byte{0xff, 'a'})
+	values := []any{
+		binary,
+		[]string{"a", binary},
+		map[string]string{binary: "v", "f": binary},
+		[]ExportedZMember{{Member: binary, Score: 2}},
+	}
+	for _, value := range values {
+		if exportValueIsUTF8(value) {
+			t.Errorf("%q reported as valid UTF-8", value)
+		}
+	}
+	if !exportValueIsUTF8([]string{"a", "б"}) {
+		t.Error("valid UTF-8 reported as invalid")
+	}
+
+	encoded, ok := exportValueBase64([]string{"a", binary}).([]string)
+	if !ok || len(encoded) != 2 {
+		t.Fatalf("unexpected encoded value %v", encoded)
+	}
+	for i, want := range []string{"a", binary} {
+		if got, err := decodeExported(encoded[i], exportEncodingBase64); err != nil || got != want {
+			t.Errorf("decoded %q (%v), want %q", got, err, want)
+		}
+	}
+	if _, err := decodeExported("a", "hex"); err == nil {
+		t.Error("unknown encoding accepted")
+	}
+}
@@ -0,0 +1,492 @@
+package redisgklib
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeReplyError - error reply of fakeRedis, recognized by go-redis as redis.Error
+type fakeReplyError string
+
+func (e fakeReplyError) Error() string { return string(e) }
+
+func (e fakeReplyError) RedisError() {}
+
+// fakeRedis - go-redis hook answering a few data commands from memory instead of
+// sending them to a server; commands it doesn't know fail with an error reply
+// handle, if set, runs first and may answer any command itself.
+type fakeRedis struct {
+	mu     sync.Mutex
+	data   map[string]string
+	handle func(ctx context.Context, cmd redis.Cmder) (handled bool, err error)
+}
+
+// newFakeRedisGk creates an instance whose commands are answered by a fakeRedis
+func newFakeRedisGk(t testing.TB, opts ...RedisAdditionalOptions) (*RedisGk, *fakeRedis) {
+	t.Helper()
+
+	conf := RedisConfConn{Host: "127.0.0.1", Port: 6379, Password: "fake"}
+	if len(opts) > 0 {
+		conf.AdditionalOptions = opts[0]
+	}
+	conf.AdditionalOptions.SkipServerSetup = true
+
+	v, err := NewRedisGk(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeRedis{data: make(map[string]string)}
+	v.redisClient.AddHook(fake)
+	t.Cleanup(func() { v.Close() })
+
+	return v, fake
+}
+
+// get returns the raw stored value of the key
+func (f *fakeRedis) get(key string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.data[key]
+	return value, ok
+}
+
+// argString converts a command argument to the string sent to Redis
+func argString(arg any) string {
+	switch a := arg.(type) {
+	case string:
+		return a
+	case []byte:
+		return string(a)
+	default:
+		return fmt.Sprint(a)
+	}
+}
+
+// process answers a single command
+func (f *fakeRedis) process(ctx context.Context, cmd redis.Cmder) error {
+	if f.handle != nil {
+		if handled, err := f.handle(ctx, cmd); handled {
+			if err != nil {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	args := make([]string, len(cmd.Args()))
+	for i, arg := range cmd.Args() {
+		args[i] = argString(arg)
+	}
+
+	switch c := cmd.(type) {
+	case *redis.StatusCmd:
+		switch {
+		case args[0] == "ping":
+			c.SetVal("PONG")
+			return nil
+		case args[0] == "set" && len(args) >= 3:
+			f.data[args[1]] = args[2]
+			c.SetVal("OK")
+			return nil
+		}
+	case *redis.StringCmd:
+		if args[0] == "get" && len(args) == 2 {
+			value, ok := f.data[args[1]]
+			if !ok {
+				c.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			c.SetVal(value)
+			return nil
+		}
+	case *redis.IntCmd:
+		if args[0] == "del" || args[0] == "exists" || args[0] == "unlink" {
+			var n int64
+			for _, key := range args[1:] {
+				if _, ok := f.data[key]; ok {
+					n++
+					if args[0] != "exists" {
+						delete(f.data, key)
+					}
+				}
+			}
+			c.SetVal(n)
+			return nil
+		}
+	case *redis.ScanCmd:
+		if args[0] == "scan" {
+			// All matching keys in one page; only strings are stored
+			pattern, keyType := "*", ""
+			for i := 2; i+1 < len(args); i += 2 {
+				switch args[i] {
+				case "match":
+					pattern = args[i+1]
+				case "type":
+					keyType = args[i+1]
+				}
+			}
+			var keys []string
+			if keyType == "" || keyType == KeyTypeString {
+				for key := range f.data {
+					if matchPattern(pattern, key) {
+						keys = append(keys, key)
+					}
+				}
+			}
+			sort.Strings(keys)
+			c.SetVal(keys, 0)
+			return nil
+		}
+	case *redis.SliceCmd:
+		if args[0] == "mget" {
+			values := make([]any, len(args)-1)
+			for i, key := range args[1:] {
+				if value, ok := f.data[key]; ok {
+					values[i] = value
+				}
+			}
+			c.SetVal(values)
+			return nil
+		}
+	}
+
+	err := fakeReplyError(fmt.Sprintf("ERR unknown command '%s'", strings.Join(args, " ")))
+	cmd.SetErr(err)
+	return err
+}
+
+// DialHook passes dialing through unchanged
+func (f *fakeRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook answers the command without calling next
+func (f *fakeRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		return f.process(ctx, cmd)
+	}
+}
+
+// ProcessPipelineHook answers the commands one by one without calling next
+func (f *fakeRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		var first error
+		for _, cmd := range cmds {
+			if err := f.process(ctx, cmd); err != nil && first == nil {
+				first = err
+			}
+		}
+		return first
+	}
+}
+
+// fakeServer - TCP server speaking enough RESP2 for connection handshakes, AUTH,
+// GET/SET and SUBSCRIBE, like a Redis server older than 6.0 (HELLO is an unknown command)
+type fakeServer struct {
+	ln net.Listener
+
+	mu        sync.Mutex
+	passwords map[string]bool // Accepted "user password" pairs, user "default" for AUTH password
+	auths     []string        // "user password" of every AUTH attempt
+	commands  []string        // Names of all received commands
+	conns     map[*fakeServerConn]bool
+	data      map[string]string
+	writers   map[string]string // User of the connection that last SET the key
+	failures  map[string]string // Error replies by command name
+	notify    string            // Last value set for notify-keyspace-events
+}
+
+// fakeServerConn - client connection of a fakeServer
+type fakeServerConn struct {
+	conn     net.Conn
+	wmu      sync.Mutex // Serializes replies and published messages
+	authed   bool
+	user     string          // Authenticated user
+	channels map[string]bool // Subscribed channels
+}
+
+// write sends raw RESP to the client
+func (c *fakeServerConn) write(reply string) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	_, err := c.conn.Write([]byte(reply))
+	return err
+}
+
+// newFakeServer starts a fakeServer accepting the password for the default user
+func newFakeServer(t *testing.T, password string) *fakeServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return serveFake(t, ln, password)
+}
+
+// serveFake starts a fakeServer on the listener
+func serveFake(t *testing.T, ln net.Listener, password string) *fakeServer {
+	s := &fakeServer{
+		ln:        ln,
+		passwords: map[string]bool{"default " + password: true},
+		conns:     make(map[*fakeServerConn]bool),
+		data:      make(map[string]string),
+		writers:   make(map[string]string),
+		failures:  make(map[string]string),
+	}
+	go s.serve()
+	t.Cleanup(func() {
+		ln.Close()
+		s.dropConnections()
+	})
+
+	return s
+}
+
+// conf returns the connection configuration of the server
+func (s *fakeServer) conf(password string) RedisConfConn {
+	addr := s.ln.Addr().(*net.TCPAddr)
+	return RedisConfConn{
+		Host:              "127.0.0.1",
+		Port:              addr.Port,
+		Password:          password,
+		AdditionalOptions: RedisAdditionalOptions{SkipServerSetup: true},
+	}
+}
+
+// setPasswords replaces the accepted passwords of the default user
+func (s *fakeServer) setPasswords(passwords ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.passwords = make(map[string]bool)
+	for _, p := range passwords {
+		s.passwords["default "+p] = true
+	}
+}
+
+// addUser accepts the password for an ACL user
+func (s *fakeServer) addUser(user, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.passwords[user+" "+password] = true
+}
+
+// writer returns the user whose connection last set the key
+func (s *fakeServer) writer(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writers[key]
+}
+
+// failCommand makes the server answer the command with the error reply
+func (s *fakeServer) failCommand(name, reply string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[name] = reply
+}
+
+// authAttempts returns "user password" of every AUTH attempt so far
+func (s *fakeServer) authAttempts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.auths...)
+}
+
+// receivedCommands returns names of all commands received so far
+func (s *fakeServer) receivedCommands() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
+
+// dropConnections closes all client connections, so clients have to dial again
+func (s *fakeServer) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.conn.Close()
+		delete(s.conns, c)
+	}
+}
+
+// subscribers returns the number of connections subscribed to the channel
+func (s *fakeServer) subscribers(channel string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for c := range s.conns {
+		if c.channels[channel] {
+			n++
+		}
+	}
+	return n
+}
+
+// waitForSubscriber waits until a connection subscribed to the channel
+func (s *fakeServer) waitForSubscriber(t *testing.T, channel string) {
+	t.Helper()
+	waitFor(t, "a subscriber of "+channel, func() bool { return s.subscribers(channel) > 0 })
+}
+
+// publish sends a message to the subscribers of the channel, like PUBLISH
+func (s *fakeServer) publish(channel, payload string) {
+	s.mu.Lock()
+	var subscribed []*fakeServerConn
+	for c := range s.conns {
+		if c.channels[channel] {
+			subscribed = append(subscribed, c)
+		}
+	}
+	s.mu.Unlock()
+
+	msg := fmt.Sprintf("*3\r\n$7\r\nmessage\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n",
+		len(channel), channel, len(payload), payload)
+	for _, c := range subscribed {
+		c.write(msg)
+	}
+}
+
+// serve accepts connections until the listener is closed
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		c := &fakeServerConn{conn: conn, channels: make(map[string]bool)}
+		s.mu.Lock()
+		s.conns[c] = true
+		s.mu.Unlock()
+		go s.handle(c)
+	}
+}
+
+// handle answers the commands of one connection
+func (s *fakeServer) handle(c *fakeServerConn) {
+	defer c.conn.Close()
+
+	r := bufio.NewReader(c.conn)
+	for {
+		args, err := readRESPCommand(r)
+		if err != nil {
+			return
+		}
+		if err := c.write(s.reply(c, args)); err != nil {
+			return
+		}
+	}
+}
+
+// reply returns the RESP reply to the command
+func (s *fakeServer) reply(c *fakeServerConn, args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := strings.ToLower(args[0])
+	s.commands = append(s.commands, name)
+	switch name {
+	case "auth":
+		cred := "default " + args[len(args)-1]
+		if len(args) == 3 {
+			cred = args[1] + " " + args[2]
+		}
+		s.auths = append(s.auths, cred)
+		if !s.passwords[cred] {
+			return "-WRONGPASS invalid username-password pair or user is disabled.\r\n"
+		}
+		c.authed = true
+		c.user, _, _ = strings.Cut(cred, " ")
+		return "+OK\r\n"
+	case "client", "select":
+		return "+OK\r\n"
+	case "hello":
+		return "-ERR unknown command 'HELLO'\r\n"
+	}
+
+	if !c.authed {
+		return "-NOAUTH Authentication required.\r\n"
+	}
+	if reply, ok := s.failures[name]; ok {
+		return "-" + reply + "\r\n"
+	}
+
+	switch {
+	case name == "ping" && len(c.channels) > 0:
+		return "*2\r\n$4\r\npong\r\n$0\r\n\r\n"
+	case name == "ping":
+		return "+PONG\r\n"
+	case name == "config":
+		if len(args) == 4 && strings.EqualFold(args[1], "set") && args[2] == "notify-keyspace-events" {
+			s.notify = args[3]
+		}
+		return "+OK\r\n"
+	case name == "subscribe" || name == "unsubscribe":
+		var reply strings.Builder
+		for _, channel := range args[1:] {
+			if name == "subscribe" {
+				c.channels[channel] = true
+			} else {
+				delete(c.channels, channel)
+			}
+			fmt.Fprintf(&reply, "*3\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n:%d\r\n",
+				len(name), name, len(channel), channel, len(c.channels))
+		}
+		return reply.String()
+	case name == "set" && len(args) >= 3:
+		s.data[args[1]] = args[2]
+		s.writers[args[1]] = c.user
+		return "+OK\r\n"
+	case name == "get" && len(args) == 2:
+		value, ok := s.data[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	}
+	return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+}
+
+// readRESPCommand reads one command sent as a RESP array of bulk strings
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("invalid command header %q", line)
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bulk header %q", line)
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
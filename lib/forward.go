@@ -0,0 +1,170 @@
+package redisgklib
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Retry settings of ForwardEvents
+const (
+	forwardAttempts     = 5
+	forwardInitialDelay = 100 * time.Millisecond
+	forwardMaxDelay     = 5 * time.Second
+)
+
+// recordFlushInterval - how often RecordEventsToWriter flushes buffered lines
+const recordFlushInterval = time.Second
+
+// ForwardEvents passes key events for keys under the pattern (nil - all keys) to sink
+// in a background goroutine, e.g. to post them to a webhook.
+// A failed call is retried up to 5 times with exponential backoff, then the event is
+// dropped and the failure is logged. Events are delivered one at a time; while the sink
+// is busy up to 100 events are buffered, further ones are dropped and counted by DroppedEvents.
+// Forwarding stops when ctx is cancelled or the instance is closed; the sink context is cancelled then.
+func (v *RedisGk) ForwardEvents(ctx context.Context, patternPath []string, sink func(context.Context, KeyEvent) error) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+	if v.listenerKeyEventManager == nil {
+		return fmt.Errorf("listener key event manager is nil")
+	}
+	if sink == nil {
+		return fmt.Errorf("sink is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pattern := "*"
+	if len(patternPath) > 0 {
+		prefixPattern, err := v.prefixPatternConvertor(patternPath)
+		if err != nil {
+			return fmt.Errorf("pattern conversion error: %w", err)
+		}
+		pattern = prefixPattern
+	}
+
+	em := v.listenerKeyEventManager
+	watcher := em.addWatcher(pattern, 100)
+
+	started := em.spawn(func() {
+		defer em.removeWatcher(watcher)
+
+		// Sink calls and retries stop on whichever is cancelled first
+		forwardCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stopOnClose := context.AfterFunc(em.ctx, cancel)
+		defer stopOnClose()
+
+		for {
+			select {
+			case <-forwardCtx.Done():
+				return
+			case event, ok := <-watcher.ch:
+				if !ok {
+					return
+				}
+				em.forwardEvent(forwardCtx, sink, event)
+			}
+		}
+	})
+	if !started {
+		em.removeWatcher(watcher)
+		return fmt.Errorf("listener key event manager is stopped")
+	}
+
+	return nil
+}
+
+// forwardEvent calls sink with retries until ctx is cancelled
+func (em *listenerKeyEventManager) forwardEvent(ctx context.Context, sink func(context.Context, KeyEvent) error, event KeyEvent) {
+	delay := forwardInitialDelay
+
+	for attempt := 1; ; attempt++ {
+		err := sink(ctx, event)
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if attempt == forwardAttempts {
+			logf(em.logger, ctx, "redisgk: dropping %s event of key %s after %d failed forward attempts: %v",
+				event.EventType, event.Key, attempt, err)
+			return
+		}
+
+		logf(em.logger, ctx, "redisgk: forwarding %s event of key %s failed, retrying in %s: %v",
+			event.EventType, event.Key, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		delay = min(delay*2, forwardMaxDelay)
+	}
+}
+
+// RecordEventsToWriter writes every key event to w as a JSON line, e.g. for an audit log
+// Lines are buffered and flushed every second and on return. A failed write is logged and
+// the buffered lines are dropped, recording goes on with the next event. Blocks until ctx is
+// cancelled or the instance is closed, then returns nil.
+func (v *RedisGk) RecordEventsToWriter(ctx context.Context, w io.Writer) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+	if v.listenerKeyEventManager == nil {
+		return fmt.Errorf("listener key event manager is nil")
+	}
+	if w == nil {
+		return fmt.Errorf("writer is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	em := v.listenerKeyEventManager
+	watcher := em.addWatcher("*", 100)
+	defer em.removeWatcher(watcher)
+
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+	flush := func() {
+		if err := buf.Flush(); err != nil {
+			logf(em.logger, ctx, "redisgk: writing recorded events failed: %v", err)
+			// Writer keeps the error, start over with an empty buffer
+			buf.Reset(w)
+		}
+	}
+	defer flush()
+
+	ticker := time.NewTicker(recordFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-em.ctx.Done():
+			return nil
+		case <-ticker.C:
+			flush()
+		case event, ok := <-watcher.ch:
+			if !ok {
+				return nil
+			}
+			if err := encoder.Encode(event); err != nil {
+				logf(em.logger, ctx, "redisgk: recording %s event of key %s failed: %v",
+					event.EventType, event.Key, err)
+				buf.Reset(w)
+			}
+		}
+	}
+}
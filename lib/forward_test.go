@@ -0,0 +1,178 @@
+package redisgklib
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// syncBuffer - bytes.Buffer safe for the recorder goroutine and the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+// newTestEventManager creates a listener that is never subscribed, events are dispatched by hand
+func newTestEventManager(t *testing.T) *listenerKeyEventManager {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"})
+	t.Cleanup(func() { client.Close() })
+
+	em := newListenerKeyEventManager(client, context.Background(), RedisAdditionalOptions{})
+	em.isRunning = true
+	t.Cleanup(em.stop)
+	return em
+}
+
+func TestRecordEventsToWriterStopsWithSlowWatcher(t *testing.T) {
+	em := newTestEventManager(t)
+	v := &RedisGk{listenerKeyEventManager: em}
+
+	// Second consumer that never reads its channel
+	slow := em.addWatcher("*", 1)
+	defer em.removeWatcher(slow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+	go func() {
+		done <- v.RecordEventsToWriter(ctx, out)
+	}()
+
+	// Wait for the recorder watcher next to the slow one
+	deadline := time.Now().Add(time.Second)
+	for {
+		em.watchersMu.RLock()
+		n := len(em.watchers)
+		em.watchersMu.RUnlock()
+		if n == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("recorder didn't register its watcher")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	const events = 5
+	for i := range events {
+		em.dispatchToWatchers(KeyEvent{
+			Key:       fmt.Sprintf("user:%d", i),
+			Value:     "v",
+			EventType: EventTypeCreated,
+			Channel:   "__keyevent@0__:set",
+		})
+	}
+
+	// The slow watcher holds one event, the rest are dropped instead of blocking dispatch
+	if got := em.droppedEvents.Load(); got != events-1 {
+		t.Fatalf("dropped events = %d, want %d", got, events-1)
+	}
+
+	// Let the recorder consume the events before it is stopped
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("RecordEventsToWriter returned %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("RecordEventsToWriter didn't stop while another watcher was blocked")
+	}
+
+	em.watchersMu.RLock()
+	remaining := len(em.watchers)
+	em.watchersMu.RUnlock()
+	if remaining != 1 {
+		t.Fatalf("watchers after stop = %d, want 1", remaining)
+	}
+
+	var lines int
+	scanner := bufio.NewScanner(bytes.NewReader(out.Bytes()))
+	for scanner.Scan() {
+		var event KeyEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", lines+1, err)
+		}
+		if want := fmt.Sprintf("user:%d", lines); event.Key != want || event.EventType != EventTypeCreated {
+			t.Fatalf("line %d = %+v, want key %s", lines+1, event, want)
+		}
+		lines++
+	}
+	if lines != events {
+		t.Fatalf("recorded %d lines, want %d", lines, events)
+	}
+}
+
+func TestForwardEventsRetriesSink(t *testing.T) {
+	logger := &testLogger{}
+	v, server := newFakeEventRedisGk(t, RedisAdditionalOptions{Logger: logger})
+
+	// Recording sink failing the first two attempts of every event
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	var delivered []string
+	sink := func(ctx context.Context, event KeyEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[event.Key]++
+		if attempts[event.Key] <= 2 {
+			return fmt.Errorf("webhook unavailable")
+		}
+		delivered = append(delivered, event.Key)
+		return nil
+	}
+	if err := v.ForwardEvents(context.Background(), []string{"users"}, sink); err != nil {
+		t.Fatal(err)
+	}
+
+	server.publish("__keyevent@0__:set", "orders:1")
+	server.publish("__keyevent@0__:set", "users:1")
+	server.publish("__keyevent@0__:del", "users:2")
+
+	waitFor(t, "delivered events", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(delivered) == 2
+	})
+
+	mu.Lock()
+	if !slices.Equal(delivered, []string{"users:1", "users:2"}) {
+		t.Errorf("delivered %q, want [users:1 users:2]", delivered)
+	}
+	if attempts["users:1"] != 3 || attempts["orders:1"] != 0 {
+		t.Errorf("attempts %v, want 3 per forwarded event and none for orders:1", attempts)
+	}
+	mu.Unlock()
+
+	// The forwarding goroutine exits on Close, ErrCloseTimeout otherwise
+	if err := v.CloseWithTimeout(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.messages) != 4 {
+		t.Errorf("logged %d retries, want 4", len(logger.messages))
+	}
+}
@@ -0,0 +1,216 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// healthChecker - background goroutine pinging Redis at a fixed interval
+type healthChecker struct {
+	client   *redis.Client
+	interval time.Duration
+	logger   Logger
+	// Called when the connection recovers after failures
+	onRecover func()
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex
+	status HealthStatus
+}
+
+// newHealthChecker creates a new health checker instance
+func newHealthChecker(client *redis.Client, interval time.Duration, logger Logger, onRecover func()) *healthChecker {
+	if client == nil || interval <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &healthChecker{
+		client:    client,
+		interval:  interval,
+		logger:    logger,
+		onRecover: onRecover,
+		ctx:       ctx,
+		cancel:    cancel,
+		status: HealthStatus{
+			Healthy:   true,
+			LastCheck: time.Now().UTC(),
+		},
+	}
+}
+
+// start starts the background health check
+func (hc *healthChecker) start() {
+	if hc == nil {
+		return
+	}
+
+	hc.wg.Add(1)
+	go hc.run()
+}
+
+// run pings Redis until the checker is stopped
+func (hc *healthChecker) run() {
+	defer hc.wg.Done()
+
+	ticker := time.NewTicker(hc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-hc.ctx.Done():
+			return
+		case <-ticker.C:
+			hc.check()
+		}
+	}
+}
+
+// check pings Redis once and updates the status
+func (hc *healthChecker) check() HealthStatus {
+	ctx, cancel := context.WithTimeout(hc.ctx, hc.interval)
+	defer cancel()
+
+	err := hc.client.Ping(ctx).Err()
+
+	hc.mu.Lock()
+	wasHealthy := hc.status.Healthy
+	hc.status.LastCheck = time.Now().UTC()
+	if err != nil {
+		hc.status.Healthy = false
+		hc.status.LastError = err
+		hc.status.ConsecutiveFailures++
+	} else {
+		hc.status.Healthy = true
+		hc.status.LastError = nil
+		hc.status.ConsecutiveFailures = 0
+	}
+	status := hc.status
+	hc.mu.Unlock()
+
+	switch {
+	case err != nil:
+		logf(hc.logger, ctx, "redisgk: health check failed (%d in a row): %v", status.ConsecutiveFailures, err)
+	case !wasHealthy:
+		logf(hc.logger, ctx, "redisgk: connection recovered, reconnecting event listener")
+		if hc.onRecover != nil {
+			hc.onRecover()
+		}
+	}
+
+	return status
+}
+
+// getStatus returns the last known status
+func (hc *healthChecker) getStatus() HealthStatus {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.status
+}
+
+// stop stops the background health check, waiting at most timeout (0 - no limit)
+// Returns false if the goroutine didn't complete in time
+func (hc *healthChecker) stop(timeout time.Duration) bool {
+	if hc == nil {
+		return true
+	}
+	hc.cancel()
+	return waitWithTimeout(&hc.wg, timeout)
+}
+
+// Health returns the connection state
+// With HealthCheckInterval set the state of the last background ping is returned,
+// otherwise Redis is pinged synchronously. Pings go through the keyless circuit
+// of the circuit breaker, so they fail with ErrCircuitOpen while it is open.
+func (v *RedisGk) Health() HealthStatus {
+	if v == nil || v.redisClient == nil {
+		return HealthStatus{
+			LastCheck: time.Now().UTC(),
+			LastError: fmt.Errorf("RedisGk instance or client is nil"),
+		}
+	}
+
+	if v.healthChecker != nil {
+		status := v.healthChecker.getStatus()
+		status.Circuit = v.circuitBreaker.getState()
+		status.OpenCircuits = v.circuitBreaker.openKeys()
+		return status
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	status := HealthStatus{LastCheck: time.Now().UTC()}
+	err := v.redisClient.Ping(ctx).Err()
+	status.Circuit = v.circuitBreaker.getState()
+	status.OpenCircuits = v.circuitBreaker.openKeys()
+	if err != nil {
+		status.LastError = err
+		status.ConsecutiveFailures = 1
+		return status
+	}
+
+	status.Healthy = true
+	return status
+}
+
+// Warmup opens n pool connections (at most PoolSize) and pings them, so the first
+// requests after startup don't pay the dial cost. A sharded instance warms every node.
+// Connections stay idle in the pool afterwards; ConnMaxIdleTime may still close them.
+func (v *RedisGk) Warmup(ctx context.Context, n int) error {
+	if v == nil || v.redisClient == nil {
+		return fmt.Errorf("RedisGk instance or client is nil")
+	}
+	if n <= 0 {
+		return fmt.Errorf("n must be > 0, got: %d", n)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var errs []error
+	for _, shard := range v.Shards() {
+		if err := warmupClient(ctx, shard.redisClient, n); err != nil {
+			errs = append(errs, fmt.Errorf("warmup of %s failed: %w", shard.redisClient.Options().Addr, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// warmupClient pings n connections held at the same time, then returns them to the pool
+// Returns the first ping error
+func warmupClient(ctx context.Context, client *redis.Client, n int) error {
+	n = min(n, client.Options().PoolSize)
+
+	conns := make([]*redis.Conn, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range conns {
+		conns[i] = client.Conn()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = conns[i].Ping(ctx).Err()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestHealthCheckFailureAndRecovery(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	var failing atomic.Bool
+	failing.Store(true)
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if cmd.Name() == "ping" && failing.Load() {
+			return true, errors.New("connection refused")
+		}
+		return false, nil
+	}
+
+	// Started here rather than by HealthCheckInterval, so the fake is in place first
+	var recovered atomic.Int32
+	v.healthChecker = newHealthChecker(v.redisClient, 20*time.Millisecond, nil, func() { recovered.Add(1) })
+	v.healthChecker.start()
+
+	waitForHealth := func(desc string, match func(HealthStatus) bool) HealthStatus {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			if status := v.Health(); match(status) {
+				return status
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("health never became %s: %+v", desc, v.Health())
+		return HealthStatus{}
+	}
+
+	status := waitForHealth("unhealthy", func(s HealthStatus) bool { return s.ConsecutiveFailures >= 2 })
+	if status.Healthy || status.LastError == nil {
+		t.Errorf("failing pings reported as %+v", status)
+	}
+
+	failing.Store(false)
+	status = waitForHealth("healthy", func(s HealthStatus) bool { return s.Healthy })
+	if status.ConsecutiveFailures != 0 || status.LastError != nil {
+		t.Errorf("recovered status %+v still carries the failure", status)
+	}
+	if recovered.Load() != 1 {
+		t.Errorf("recovery callback called %d times, want 1", recovered.Load())
+	}
+}
+
+func TestWarmupFillsPool(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	conf := server.conf("secret")
+	conf.AdditionalOptions.PoolSize = 4
+
+	v, err := NewRedisGk(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	if err := v.Warmup(context.Background(), 3); err != nil {
+		t.Fatal(err)
+	}
+	if stats := v.redisClient.PoolStats(); stats.TotalConns != 3 || stats.IdleConns != 3 {
+		t.Errorf("after Warmup(3): %d connections, %d idle, want 3 idle", stats.TotalConns, stats.IdleConns)
+	}
+
+	// Capped at PoolSize
+	if err := v.Warmup(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+	if stats := v.redisClient.PoolStats(); stats.TotalConns != 4 {
+		t.Errorf("after Warmup(10): %d connections, want PoolSize 4", stats.TotalConns)
+	}
+
+	if err := v.Warmup(context.Background(), 0); err == nil {
+		t.Error("Warmup(0) succeeded")
+	}
+}
@@ -0,0 +1,197 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// slowOpHook - go-redis hook reporting commands slower than the threshold
+type slowOpHook struct {
+	threshold time.Duration
+	logger    Logger
+	metrics   MetricsCollector
+}
+
+// newSlowOpHook creates a new slow operation hook, nil if the threshold is not set
+func newSlowOpHook(threshold time.Duration, logger Logger, metrics MetricsCollector) *slowOpHook {
+	if threshold <= 0 {
+		return nil
+	}
+	return &slowOpHook{threshold: threshold, logger: logger, metrics: metrics}
+}
+
+// DialHook passes dialing through unchanged
+func (h *slowOpHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook measures a single command
+func (h *slowOpHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.observe(ctx, cmd.FullName(), commandKey(cmd), time.Since(start))
+		return err
+	}
+}
+
+// ProcessPipelineHook measures a pipeline or transaction as one operation
+// reported with the "pipeline" command name and the first key of its commands
+func (h *slowOpHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+
+		key := ""
+		for _, cmd := range cmds {
+			if key = commandKey(cmd); key != "" {
+				break
+			}
+		}
+		h.observe(ctx, "pipeline", key, time.Since(start))
+		return err
+	}
+}
+
+// observe reports the operation if it exceeded the threshold
+func (h *slowOpHook) observe(ctx context.Context, command, key string, duration time.Duration) {
+	if duration <= h.threshold {
+		return
+	}
+
+	logf(h.logger, ctx, "redisgk: slow operation %s on key %q took %s (threshold %s)",
+		command, key, duration, h.threshold)
+	if h.metrics != nil {
+		h.metrics.ObserveSlowOp(ctx, command, key, duration)
+	}
+}
+
+// keylessCommands - commands whose first argument is not a key
+var keylessCommands = map[string]bool{
+	"auth": true, "client": true, "config": true, "dbsize": true, "echo": true,
+	"hello": true, "info": true, "ping": true, "scan": true, "select": true,
+	"time": true, "wait": true, "multi": true, "exec": true,
+}
+
+// commandKey returns the first key of the command, empty if it has none
+func commandKey(cmd redis.Cmder) string {
+	args := cmd.Args()
+	name := strings.ToLower(cmd.Name())
+
+	switch name {
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+		// EVAL script numkeys key [key ...]
+		if len(args) > 3 && fmt.Sprint(args[2]) != "0" {
+			return fmt.Sprint(args[3])
+		}
+		return ""
+	case "lmpop", "zmpop", "sintercard", "zintercard":
+		// LMPOP numkeys key [key ...]
+		if len(args) > 2 {
+			return fmt.Sprint(args[2])
+		}
+		return ""
+	}
+
+	if keylessCommands[name] || len(args) < 2 {
+		return ""
+	}
+	return fmt.Sprint(args[1])
+}
+
+// errorHook - go-redis hook wrapping command errors into library error categories
+// The original error stays in the chain, so errors.As(err, &redis.Error) still works
+type errorHook struct{}
+
+// DialHook passes dialing through unchanged
+func (errorHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook classifies the error of a single command
+// go-redis sets cmd.Err() only after all hooks returned, so the returned error is classified.
+// HELLO errors stay unwrapped: go-redis falls back to AUTH only for a bare server reply.
+func (errorHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if cmd.Name() == "hello" {
+			return err
+		}
+		if classified := classifyError(err); classified != err {
+			cmd.SetErr(classified)
+			return classified
+		}
+		return err
+	}
+}
+
+// ProcessPipelineHook classifies errors of all commands of a pipeline
+func (errorHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		var first error
+		for _, cmd := range cmds {
+			if classified := classifyError(cmd.Err()); classified != cmd.Err() {
+				cmd.SetErr(classified)
+				if first == nil {
+					first = classified
+				}
+			}
+		}
+		if first != nil && err != nil {
+			return first
+		}
+		return classifyError(err)
+	}
+}
+
+// classifyError wraps command errors into error categories, so callers can tell
+// failures worth a retry (ErrConnection, ErrTimeout) from command failures
+// (ErrWrongType, ErrServer). redis.Nil, cancellation, a closed client, transaction
+// conflicts and ErrCrossShard of a sharded instance are returned unchanged.
+func classifyError(err error) error {
+	if err == nil || err == redis.Nil || errors.Is(err, context.Canceled) ||
+		errors.Is(err, redis.ErrClosed) || errors.Is(err, redis.TxFailedErr) ||
+		errors.Is(err, ErrCrossShard) {
+		return err
+	}
+	// Already classified by an earlier pass (e.g. a pipeline error set on commands)
+	for _, class := range []error{ErrConnection, ErrTimeout, ErrWrongType, ErrServer} {
+		if errors.Is(err, class) {
+			return err
+		}
+	}
+
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		msg := redisErr.Error()
+		switch {
+		case strings.HasPrefix(msg, "WRONGTYPE "):
+			return fmt.Errorf("%w: %w", ErrWrongType, err)
+		case strings.HasPrefix(msg, "OOM "):
+			return fmt.Errorf("%w: %w: %w", ErrServer, ErrOutOfMemory, err)
+		case strings.HasPrefix(msg, "MOVED "), strings.HasPrefix(msg, "ASK "):
+			return fmt.Errorf("%w: %w: %w", ErrServer, ErrClusterRedirect, err)
+		default:
+			return fmt.Errorf("%w: %w", ErrServer, err)
+		}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, redis.ErrPoolTimeout) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+
+	return fmt.Errorf("%w: %w", ErrConnection, err)
+}
@@ -0,0 +1,210 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestClassifyError(t *testing.T) {
+	unchanged := []error{
+		redis.Nil,
+		context.Canceled,
+		fmt.Errorf("dial: %w", context.Canceled),
+		redis.ErrClosed,
+		redis.TxFailedErr,
+		fmt.Errorf("%w: keys of rename are on different nodes", ErrCrossShard),
+	}
+	for _, err := range unchanged {
+		if got := classifyError(err); got != err {
+			t.Errorf("classifyError(%v) = %v, want it unchanged", err, got)
+		}
+	}
+
+	wrapped := map[error]error{
+		io.EOF:                   ErrConnection,
+		context.DeadlineExceeded: ErrTimeout,
+		redis.ErrPoolTimeout:     ErrTimeout,
+	}
+	for err, class := range wrapped {
+		got := classifyError(err)
+		if !errors.Is(got, class) || !errors.Is(got, err) {
+			t.Errorf("classifyError(%v) = %v, want %v wrapping it", err, got, class)
+		}
+		if errors.Is(got, ErrConnection) && class != ErrConnection {
+			t.Errorf("classifyError(%v) = %v, also wrapped as ErrConnection", err, got)
+		}
+	}
+}
+
+func TestConnectWithoutHello(t *testing.T) {
+	server := newFakeServer(t, "secret")
+
+	v, err := NewRedisGk(server.conf("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	// The HELLO error must reach go-redis unwrapped to fall back to AUTH
+	if err := v.SetString([]string{"k"}, "v"); err != nil {
+		t.Fatalf("SetString against a server without HELLO: %v", err)
+	}
+	value, err := v.GetString([]string{"k"})
+	if err != nil || value != "v" {
+		t.Fatalf("GetString: got %q, %v, want %q", value, err, "v")
+	}
+	if auths := server.authAttempts(); len(auths) == 0 || auths[0] != "default secret" {
+		t.Errorf("AUTH attempts %q, want the configured password", auths)
+	}
+}
+
+func TestErrorHookClassifiesServerReplies(t *testing.T) {
+	server := newFakeServer(t, "secret")
+
+	v, err := NewRedisGk(server.conf("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	// A reply read from the network, not an error set by a hook
+	cmd := v.redisClient.Do(ctx, "nosuchcommand")
+	if !errors.Is(cmd.Err(), ErrServer) {
+		t.Errorf("command error: got %v, want ErrServer", cmd.Err())
+	}
+	var redisErr redis.Error
+	if !errors.As(cmd.Err(), &redisErr) {
+		t.Errorf("command error %v does not keep the redis.Error", cmd.Err())
+	}
+}
+
+func TestErrorHookOutOfMemory(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	server.failCommand("set", "OOM command not allowed when used memory > 'maxmemory'.")
+
+	v, err := NewRedisGk(server.conf("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	err = v.SetString([]string{"cache", "1"}, "value")
+	if !errors.Is(err, ErrOutOfMemory) || !errors.Is(err, ErrServer) {
+		t.Errorf("SET on a full server: got %v, want ErrOutOfMemory", err)
+	}
+	if _, err := v.GetString([]string{"cache", "1"}); errors.Is(err, ErrOutOfMemory) {
+		t.Errorf("GET: got %v, reads are not rejected", err)
+	}
+}
+
+func TestErrorCategories(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	server.failCommand("get", "WRONGTYPE Operation against a key holding the wrong kind of value")
+
+	v, err := NewRedisGk(server.conf("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	_, err = v.GetString([]string{"queue"})
+	if !errors.Is(err, ErrWrongType) || errors.Is(err, ErrConnection) {
+		t.Errorf("WRONGTYPE reply: got %v, want ErrWrongType", err)
+	}
+
+	timeout := v.WithTimeout(time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, err := timeout.GetString([]string{"queue"}); !errors.Is(err, ErrTimeout) {
+		t.Errorf("context deadline: got %v, want ErrTimeout", err)
+	}
+
+	// The server goes away, new connections can't be dialed
+	server.ln.Close()
+	server.dropConnections()
+	_, err = v.GetString([]string{"queue"})
+	if !errors.Is(err, ErrConnection) || errors.Is(err, ErrTimeout) {
+		t.Errorf("dial failure: got %v, want ErrConnection", err)
+	}
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		t.Errorf("dial failure %v does not keep the net.OpError", err)
+	}
+}
+
+func TestErrorHookClusterRedirect(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	server.failCommand("get", "MOVED 3999 127.0.0.1:6381")
+	server.failCommand("set", "ASK 3999 127.0.0.1:6381")
+
+	v, err := NewRedisGk(server.conf("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	if _, err := v.GetString([]string{"users", "1"}); !errors.Is(err, ErrClusterRedirect) {
+		t.Errorf("MOVED reply: got %v, want ErrClusterRedirect", err)
+	}
+	if err := v.SetString([]string{"users", "1"}, "Alice"); !errors.Is(err, ErrClusterRedirect) {
+		t.Errorf("ASK reply: got %v, want ErrClusterRedirect", err)
+	}
+}
+
+// slowOpRecorder - MetricsCollector recording slow operations
+type slowOpRecorder struct {
+	mu  sync.Mutex
+	ops []string // "command key"
+}
+
+func (r *slowOpRecorder) ObserveSlowOp(_ context.Context, command, key string, _ time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ops = append(r.ops, command+" "+key)
+}
+
+func TestSlowOpThreshold(t *testing.T) {
+	logger := &testLogger{}
+	metrics := &slowOpRecorder{}
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{
+		SlowOpThreshold:  20 * time.Millisecond,
+		Logger:           logger,
+		MetricsCollector: metrics,
+	})
+
+	// Only GET is artificially slow
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if cmd.Name() == "get" {
+			time.Sleep(30 * time.Millisecond)
+		}
+		return false, nil
+	}
+
+	if err := v.SetString([]string{"users", "1"}, "v"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.GetString([]string{"users", "1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if want := []string{"get users:1"}; !slices.Equal(metrics.ops, want) {
+		t.Errorf("slow operations %q, want %q", metrics.ops, want)
+	}
+	if len(logger.messages) != 1 || !strings.Contains(logger.messages[0], "slow operation") {
+		t.Errorf("logged %q, want one slow operation warning", logger.messages)
+	}
+}
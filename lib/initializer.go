@@ -75,8 +75,8 @@ func (ri *redisInitializer) setupKeyExpirationNotifications() error {
 	defer cancel()
 
 	// Set configuration for keyevent notifications only
-	// E = key expiration events, g = generic commands
-	err := ri.client.ConfigSet(ctx, "notify-keyspace-events", "Exg").Err()
+	// E = keyevent channels, $ = string commands (set), x = expired events, g = generic commands
+	err := ri.client.ConfigSet(ctx, "notify-keyspace-events", "E$xg").Err()
 	if err != nil {
 		return fmt.Errorf("error setting notify-keyspace-events: %w", err)
 	}
@@ -3,8 +3,11 @@ package redisgklib
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -19,10 +22,43 @@ type listenerKeyEventManager struct {
 	mu           sync.RWMutex
 	isRunning    bool
 	wg           sync.WaitGroup // Add WaitGroup for proper goroutine completion
+	shadowKeys   bool           // Read companion keys on expired events
+	channels     []string       // Subscribed keyevent channels
+	dbs          []int          // Databases whose events are subscribed
+	dbClients    *dbClients     // Clients reading values of events from other databases
+	reconnectCh  chan struct{}  // Requests resubscription of the listener
+	logger       Logger
+
+	// Events are drained but discarded while paused
+	paused atomic.Bool
+
+	// Buffer between subscription draining and delivery to consumers
+	queue          chan KeyEvent
+	overflowPolicy OverflowPolicy
+	droppedEvents  atomic.Uint64
+
+	// Backlog warning, re-armed when the queue drains below half of the mark
+	highWater      int
+	aboveHighWater atomic.Bool
+	metrics        MetricsCollector
+
+	// Main channel is fed only after it was requested by the user,
+	// so that watchers keep working when nobody reads it
+	chanRequested atomic.Bool
+
+	// Additional subscribers receiving events for matching keys
+	watchersMu sync.RWMutex
+	watchers   []*eventWatcher
+}
+
+// eventWatcher - additional subscriber of key events filtered by pattern
+type eventWatcher struct {
+	pattern string
+	ch      chan KeyEvent
 }
 
 // newListenerKeyEventManager creates a new key expiration notification manager
-func newListenerKeyEventManager(client *redis.Client, ctx context.Context) *listenerKeyEventManager {
+func newListenerKeyEventManager(client *redis.Client, ctx context.Context, opts RedisAdditionalOptions) *listenerKeyEventManager {
 	if client == nil {
 		return nil
 	}
@@ -30,15 +66,86 @@ func newListenerKeyEventManager(client *redis.Client, ctx context.Context) *list
 		ctx = context.Background()
 	}
 
+	queueSize := opts.EventQueueSize
+	if queueSize <= 0 {
+		queueSize = 1000
+	}
+
+	overflowPolicy := opts.EventOverflowPolicy
+	if overflowPolicy == "" {
+		overflowPolicy = OverflowDropNewest
+	}
+
+	highWater := opts.EventQueueHighWater
+	if highWater <= 0 {
+		highWater = max(queueSize*8/10, 1)
+	}
+	highWater = min(highWater, queueSize)
+
+	dbs := []int{client.Options().DB}
+	if len(opts.EventDBs) > 0 {
+		dbs = nil
+		for _, db := range opts.EventDBs {
+			if !slices.Contains(dbs, db) {
+				dbs = append(dbs, db)
+			}
+		}
+	}
+
 	managerCtx, cancel := context.WithCancel(ctx)
 
 	return &listenerKeyEventManager{
-		client:       client,
-		ctx:          managerCtx,
-		cancel:       cancel,
-		keyEventChan: make(chan KeyEvent), // Unbuffered channel for simple forwarding
-		isRunning:    false,
+		client:         client,
+		ctx:            managerCtx,
+		cancel:         cancel,
+		keyEventChan:   make(chan KeyEvent), // Unbuffered channel for simple forwarding
+		isRunning:      false,
+		shadowKeys:     opts.ShadowKeys || opts.ArchiveExpiredValues,
+		dbs:            dbs,
+		reconnectCh:    make(chan struct{}, 1),
+		logger:         opts.Logger,
+		queue:          make(chan KeyEvent, queueSize),
+		overflowPolicy: overflowPolicy,
+		highWater:      highWater,
+		metrics:        opts.MetricsCollector,
+	}
+}
+
+// keyEventEvents - keyevent notifications the listener subscribes to
+var keyEventEvents = []string{
+	"expire",      // TTL setting events
+	"expired",     // Key expiration events
+	"set",         // Creation/update events
+	"del",         // Deletion events
+	"rename_from", // Rename events, old key name
+	"rename_to",   // Rename events, new key name
+}
+
+// keyEventChannels returns keyevent channel names of the database
+func keyEventChannels(db int) []string {
+	channels := make([]string, 0, len(keyEventEvents))
+	for _, event := range keyEventEvents {
+		channels = append(channels, fmt.Sprintf("__keyevent@%d__:%s", db, event))
 	}
+	return channels
+}
+
+// parseKeyEventChannel extracts database index and event name from a keyevent channel
+// such as __keyevent@3__:expired
+func parseKeyEventChannel(channel string) (int, string, bool) {
+	rest, ok := strings.CutPrefix(channel, "__keyevent@")
+	if !ok {
+		return 0, "", false
+	}
+	dbStr, event, ok := strings.Cut(rest, "__:")
+	if !ok {
+		return 0, "", false
+	}
+	db, err := strconv.Atoi(dbStr)
+	if err != nil || db < 0 {
+		return 0, "", false
+	}
+	return db, event, true
 }
 
 // start starts the key  notification listener
@@ -55,20 +162,21 @@ func (em *listenerKeyEventManager) start() error {
 		return nil
 	}
 
-	// Subscribe to specific Redis keyevent channels
-	channels := []string{
-		"__keyevent@0__:expire",  // TTL setting events
-		"__keyevent@0__:expired", // Key expiration events
-		"__keyevent@0__:set",     // Creation/update events
-		"__keyevent@0__:del",     // Deletion events
+	// Subscribe to specific Redis keyevent channels of the listened databases
+	var channels []string
+	for _, db := range em.dbs {
+		channels = append(channels, keyEventChannels(db)...)
 	}
 
+	em.channels = channels
+
 	// Create subscription to key event notification channels
 	pubsub := em.client.Subscribe(em.ctx, channels...)
 
-	// Start goroutine for processing notifications
-	em.wg.Add(1)
+	// Start goroutines for draining the subscription and delivering events
+	em.wg.Add(2)
 	go em.listenForEvents(pubsub)
+	go em.deliverEvents()
 
 	em.isRunning = true
 	return nil
@@ -85,9 +193,99 @@ func (em *listenerKeyEventManager) listenForEvents(pubsub *redis.PubSub) {
 		select {
 		case <-em.ctx.Done():
 			return
-		case msg := <-pubsub.Channel():
+		case <-em.reconnectCh:
+			// Replace subscription after connection loss
+			pubsub.Close()
+			pubsub = em.client.Subscribe(em.ctx, em.channels...)
+		case msg, ok := <-pubsub.Channel():
+			if !ok {
+				return
+			}
+			if em.paused.Load() {
+				continue
+			}
 			event := em.processEventMessage(msg)
 			if event.EventType != EventTypeUnknown {
+				if !em.enqueue(event) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// enqueue puts event into the delivery queue according to the overflow policy
+// Returns false if the manager was stopped while waiting
+func (em *listenerKeyEventManager) enqueue(event KeyEvent) bool {
+	defer em.checkHighWater()
+
+	select {
+	case em.queue <- event:
+		return true
+	default:
+	}
+
+	switch em.overflowPolicy {
+	case OverflowBlock:
+		select {
+		case em.queue <- event:
+			return true
+		case <-em.ctx.Done():
+			return false
+		}
+	case OverflowDropOldest:
+		// Free one slot, the consumer may take it first, then the new event is dropped
+		select {
+		case <-em.queue:
+			em.onDrop()
+		default:
+		}
+		select {
+		case em.queue <- event:
+		default:
+			em.onDrop()
+		}
+	default:
+		em.onDrop()
+	}
+
+	return true
+}
+
+// checkHighWater reports the queue reaching the high-water mark once per crossing
+func (em *listenerKeyEventManager) checkHighWater() {
+	depth := len(em.queue)
+	if depth < em.highWater || !em.aboveHighWater.CompareAndSwap(false, true) {
+		return
+	}
+
+	logf(em.logger, em.ctx, "redisgk: event queue reached %d of %d events, consumers are falling behind",
+		depth, cap(em.queue))
+	if collector, ok := em.metrics.(EventQueueMetricsCollector); ok {
+		collector.ObserveEventQueueHighWater(em.ctx, depth, cap(em.queue))
+	}
+}
+
+// onDrop counts a dropped event and logs the first drop of every thousand
+func (em *listenerKeyEventManager) onDrop() {
+	if n := em.droppedEvents.Add(1); n%1000 == 1 {
+		logf(em.logger, em.ctx, "redisgk: event queue or watcher buffer is full, %d events dropped", n)
+	}
+}
+
+// deliverEvents forwards queued events to the main channel and watchers
+func (em *listenerKeyEventManager) deliverEvents() {
+	defer em.wg.Done()
+
+	for {
+		select {
+		case <-em.ctx.Done():
+			return
+		case event := <-em.queue:
+			if em.aboveHighWater.Load() && len(em.queue) < em.highWater/2 {
+				em.aboveHighWater.Store(false)
+			}
+			if em.chanRequested.Load() {
 				// Simply forward event to user (block until user reads)
 				select {
 				case em.keyEventChan <- event:
@@ -95,6 +293,7 @@ func (em *listenerKeyEventManager) listenForEvents(pubsub *redis.PubSub) {
 					return
 				}
 			}
+			em.dispatchToWatchers(event)
 		}
 	}
 }
@@ -106,18 +305,24 @@ func (em *listenerKeyEventManager) processEventMessage(msg *redis.Message) KeyEv
 
 	channelName := msg.Channel
 	// Handle keyevent events
-	if strings.HasPrefix(msg.Channel, "__keyevent@0__:") {
+	db, eventName, isKeyEvent := parseKeyEventChannel(msg.Channel)
+	if isKeyEvent {
 		key = msg.Payload
 		// Determine event type from keyevent channel
-		if strings.HasSuffix(msg.Channel, ":expire") {
+		switch eventName {
+		case "expire":
 			eventType = EventTypeExpire
-		} else if strings.HasSuffix(msg.Channel, ":expired") {
+		case "expired":
 			eventType = EventTypeExpired
-		} else if strings.HasSuffix(msg.Channel, ":set") {
+		case "set":
 			eventType = EventTypeCreated
-		} else if strings.HasSuffix(msg.Channel, ":del") {
+		case "del":
 			eventType = EventTypeDeleted
-		} else {
+		case "rename_from":
+			eventType = EventTypeRenamedFrom
+		case "rename_to":
+			eventType = EventTypeRenamedTo
+		default:
 			eventType = EventTypeUnknown
 		}
 	} else {
@@ -126,32 +331,58 @@ func (em *listenerKeyEventManager) processEventMessage(msg *redis.Message) KeyEv
 		key = msg.Payload
 	}
 
+	// Companion keys are internal and never reported to the user
+	if isShadowKey(key) {
+		return KeyEvent{Key: key, EventType: EventTypeUnknown, Channel: channelName, DB: db}
+	}
+
 	// Get key value if possible
 	value := ""
-	value, _ = em.getKeyValue(key)
+	value, _ = em.getKeyValue(db, key)
 
 	now := time.Now().UTC()
 
-	return KeyEvent{
+	event := KeyEvent{
 		Key:       key,
 		Value:     value,
 		EventType: eventType,
 		Timestamp: now,
 		Channel:   channelName,
+		DB:        db,
+	}
+
+	// Restore original TTL, creation time and archived value from the shadow key
+	if eventType == EventTypeExpired && em.shadowKeys {
+		if record, err := em.readShadow(db, key); err == nil {
+			event.OriginalTTL = record.ttl
+			event.CreatedAt = record.createdAt
+			if record.hasValue {
+				event.Value = record.value
+			}
+		}
 	}
+
+	return event
 }
 
 // stop stops the notification listener
 func (em *listenerKeyEventManager) stop() {
+	em.stopWithTimeout(0)
+}
+
+// stopWithTimeout stops the notification listener, waiting at most timeout
+// for goroutines to complete (0 - no limit)
+// Returns false if goroutines didn't complete in time, channels are left open then
+func (em *listenerKeyEventManager) stopWithTimeout(timeout time.Duration) bool {
 	if em == nil {
-		return
+		return true
 	}
 
 	em.mu.Lock()
 	defer em.mu.Unlock()
 
 	if !em.isRunning {
-		return
+		return true
 	}
 
 	// Cancel context
@@ -159,15 +390,104 @@ func (em *listenerKeyEventManager) stop() {
 		em.cancel()
 	}
 
+	em.isRunning = false
+
 	// Wait for all goroutines to complete
-	em.wg.Wait()
+	if !waitWithTimeout(&em.wg, timeout) {
+		// Closing channels now could panic a goroutine still sending to them
+		return false
+	}
 
 	// Close channel only after all goroutines complete
 	if em.keyEventChan != nil {
 		close(em.keyEventChan)
 	}
 
-	em.isRunning = false
+	em.watchersMu.Lock()
+	for _, w := range em.watchers {
+		close(w.ch)
+	}
+	em.watchers = nil
+	em.watchersMu.Unlock()
+
+	return true
+}
+
+// reconnect requests resubscription of the listener to keyevent channels
+func (em *listenerKeyEventManager) reconnect() {
+	if em == nil {
+		return
+	}
+	select {
+	case em.reconnectCh <- struct{}{}:
+	default:
+		// Reconnect is already pending
+	}
+}
+
+// addWatcher registers an additional subscriber for keys matching the pattern
+// The subscriber must call removeWatcher when it stops reading
+func (em *listenerKeyEventManager) addWatcher(pattern string, buffer int) *eventWatcher {
+	w := &eventWatcher{
+		pattern: pattern,
+		ch:      make(chan KeyEvent, buffer),
+	}
+
+	em.watchersMu.Lock()
+	em.watchers = append(em.watchers, w)
+	em.watchersMu.Unlock()
+
+	return w
+}
+
+// removeWatcher unregisters the subscriber
+func (em *listenerKeyEventManager) removeWatcher(w *eventWatcher) {
+	em.watchersMu.Lock()
+	defer em.watchersMu.Unlock()
+
+	for i, existing := range em.watchers {
+		if existing == w {
+			em.watchers = append(em.watchers[:i], em.watchers[i+1:]...)
+			return
+		}
+	}
+}
+
+// dispatchToWatchers forwards event to all matching watchers
+// A watcher whose buffer is full misses the event, which is counted as dropped,
+// so one slow watcher never holds back the others
+func (em *listenerKeyEventManager) dispatchToWatchers(event KeyEvent) {
+	em.watchersMu.RLock()
+	defer em.watchersMu.RUnlock()
+
+	for _, w := range em.watchers {
+		if !matchPattern(w.pattern, event.Key) {
+			continue
+		}
+		select {
+		case w.ch <- event:
+		default:
+			em.onDrop()
+		}
+	}
+}
+
+// spawn runs fn in a goroutine waited for by stop
+// Returns false without running fn if the listener is not running
+func (em *listenerKeyEventManager) spawn(fn func()) bool {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	if !em.isRunning {
+		return false
+	}
+
+	em.wg.Add(1)
+	go func() {
+		defer em.wg.Done()
+		fn()
+	}()
+	return true
 }
 
 // getKeyEventChannel returns channel for receiving key event notifications
@@ -175,16 +495,22 @@ func (em *listenerKeyEventManager) getKeyEventChannel() <-chan KeyEvent {
 	if em == nil {
 		return nil
 	}
+	em.chanRequested.Store(true)
 	return em.keyEventChan
 }
 
-// getKeyValue tries to get the value of the key
-func (em *listenerKeyEventManager) getKeyValue(key string) (string, error) {
+// getKeyValue tries to get the value of the key in the database
+func (em *listenerKeyEventManager) getKeyValue(db int, key string) (string, error) {
 	// Fast attempt to get the value with a short timeout
 	ctx, cancel := context.WithTimeout(em.ctx, 5*time.Second)
 	defer cancel()
 
-	result, err := em.client.Get(ctx, key).Result()
+	client, err := em.clientForDB(ctx, db)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := client.Get(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return "", fmt.Errorf("key %s not found", key)
@@ -194,3 +520,11 @@ func (em *listenerKeyEventManager) getKeyValue(key string) (string, error) {
 
 	return result, nil
 }
+
+// clientForDB returns the client of the database an event came from
+func (em *listenerKeyEventManager) clientForDB(ctx context.Context, db int) (*redis.Client, error) {
+	if em.dbClients == nil || db == em.client.Options().DB {
+		return em.client, nil
+	}
+	return em.dbClients.get(ctx, db)
+}
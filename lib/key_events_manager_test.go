@@ -0,0 +1,241 @@
+package redisgklib
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newFakeEventRedisGk connects an instance with a key event listener to a fakeServer,
+// waiting until the listener subscribed to the expired events of database 0
+func newFakeEventRedisGk(t *testing.T, opts RedisAdditionalOptions) (*RedisGk, *fakeServer) {
+	t.Helper()
+
+	server := newFakeServer(t, "secret")
+	conf := server.conf("secret")
+	conf.AdditionalOptions = opts
+
+	v, err := NewRedisGk(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.waitForSubscriber(t, "__keyevent@0__:expired")
+
+	return v, server
+}
+
+func TestCloseWithTimeoutNonDrainingConsumer(t *testing.T) {
+	v, server := newFakeEventRedisGk(t, RedisAdditionalOptions{
+		EventQueueSize:      4,
+		EventOverflowPolicy: OverflowBlock,
+	})
+
+	// Requested but never read: delivery and then the listener block
+	events := v.ListenChannelKeyEventManager()
+	for i := range 20 {
+		server.publish("__keyevent@0__:expired", fmt.Sprintf("key%d", i))
+	}
+	waitFor(t, "a full event queue", func() bool {
+		return len(v.listenerKeyEventManager.queue) == 4
+	})
+
+	start := time.Now()
+	if err := v.CloseWithTimeout(time.Second); err != nil {
+		t.Fatalf("CloseWithTimeout: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("CloseWithTimeout returned after %s", elapsed)
+	}
+
+	// Goroutines exited cleanly, so the channel is closed
+	for range events {
+	}
+}
+
+func TestListenerDrainsWithoutReader(t *testing.T) {
+	v, server := newFakeEventRedisGk(t, RedisAdditionalOptions{EventQueueSize: 10})
+	defer v.Close()
+
+	// Requested but not read for a while
+	events := v.ListenChannelKeyEventManager()
+	for i := range 100 {
+		server.publish("__keyevent@0__:expired", fmt.Sprintf("key%d", i))
+	}
+
+	// The subscription is drained: events beyond the queue and the one
+	// waiting for the reader are dropped instead of blocking the listener
+	waitFor(t, "dropped events", func() bool { return v.DroppedEvents() == 89 })
+
+	server.publish("__keyevent@0__:expired", "late")
+	waitFor(t, "the late event to be dropped", func() bool { return v.DroppedEvents() == 90 })
+
+	// The reader gets the buffered events once it starts reading
+	for i := range 11 {
+		event := <-events
+		if want := fmt.Sprintf("key%d", i); event.Key != want || event.EventType != EventTypeExpired {
+			t.Errorf("event %d: got %s %s, want %s expired", i, event.Key, event.EventType, want)
+		}
+	}
+}
+
+func TestRenameEvents(t *testing.T) {
+	v, server := newFakeEventRedisGk(t, RedisAdditionalOptions{})
+	defer v.Close()
+
+	events := v.ListenChannelKeyEventManager()
+	server.waitForSubscriber(t, "__keyevent@0__:rename_to")
+
+	// RENAME old new notifies rename_from with the old key, then rename_to with the new one
+	server.publish("__keyevent@0__:rename_from", "users:old")
+	server.publish("__keyevent@0__:rename_to", "users:new")
+
+	from := waitForEvent(t, events, 5*time.Second, func(KeyEvent) bool { return true })
+	if from.EventType != EventTypeRenamedFrom || from.Key != "users:old" {
+		t.Errorf("first event %s %s, want %s users:old", from.EventType, from.Key, EventTypeRenamedFrom)
+	}
+	to := waitForEvent(t, events, 5*time.Second, func(KeyEvent) bool { return true })
+	if to.EventType != EventTypeRenamedTo || to.Key != "users:new" {
+		t.Errorf("second event %s %s, want %s users:new", to.EventType, to.Key, EventTypeRenamedTo)
+	}
+}
+
+func TestPauseAndResumeEvents(t *testing.T) {
+	v, server := newFakeEventRedisGk(t, RedisAdditionalOptions{})
+	defer v.Close()
+
+	events := v.ListenChannelKeyEventManager()
+
+	v.PauseEvents()
+	for i := range 50 {
+		server.publish("__keyevent@0__:set", fmt.Sprintf("bulk%d", i))
+	}
+	// The listener has no observable progress while paused, give it time to discard the bulk
+	time.Sleep(200 * time.Millisecond)
+	v.ResumeEvents()
+
+	server.publish("__keyevent@0__:set", "after")
+	event := waitForEvent(t, events, 5*time.Second, func(KeyEvent) bool { return true })
+	if event.Key != "after" {
+		t.Errorf("first event after resume is %s, events during the pause were delivered", event.Key)
+	}
+	if dropped := v.DroppedEvents(); dropped != 0 {
+		t.Errorf("paused events counted as dropped: %d", dropped)
+	}
+}
+
+func TestKeyEventDB(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	conf := server.conf("secret")
+	conf.DB = 3
+	conf.AdditionalOptions = RedisAdditionalOptions{}
+
+	v, err := NewRedisGk(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	events := v.ListenChannelKeyEventManager()
+	server.waitForSubscriber(t, "__keyevent@3__:expired")
+	server.publish("__keyevent@3__:expired", "sessions:1")
+
+	event := waitForEvent(t, events, 5*time.Second, func(KeyEvent) bool { return true })
+	if event.DB != 3 || event.Key != "sessions:1" {
+		t.Errorf("event from DB %d key %s, want DB 3 key sessions:1", event.DB, event.Key)
+	}
+}
+
+// highWaterRecorder - MetricsCollector recording event queue high-water reports
+type highWaterRecorder struct {
+	mu      sync.Mutex
+	reports [][2]int // depth, capacity
+}
+
+func (r *highWaterRecorder) ObserveSlowOp(context.Context, string, string, time.Duration) {}
+
+func (r *highWaterRecorder) ObserveEventQueueHighWater(_ context.Context, depth, capacity int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, [2]int{depth, capacity})
+}
+
+func (r *highWaterRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.reports)
+}
+
+func TestEventQueueDepthAndHighWater(t *testing.T) {
+	recorder := &highWaterRecorder{}
+	v, server := newFakeEventRedisGk(t, RedisAdditionalOptions{
+		EventQueueSize:      10,
+		EventQueueHighWater: 8,
+		MetricsCollector:    recorder,
+	})
+	defer v.Close()
+
+	// Requested but not read: one event waits for the reader, the rest fill the queue
+	events := v.ListenChannelKeyEventManager()
+	for i := range 20 {
+		server.publish("__keyevent@0__:expired", fmt.Sprintf("key%d", i))
+	}
+	waitFor(t, "a full event queue", func() bool { return v.EventQueueDepth() == 10 })
+
+	recorder.mu.Lock()
+	reports := slices.Clone(recorder.reports)
+	recorder.mu.Unlock()
+	if len(reports) != 1 || reports[0] != [2]int{8, 10} {
+		t.Errorf("high-water reports %v, want one at depth 8 of 10", reports)
+	}
+
+	// Draining below half of the mark re-arms the report
+	for range 11 {
+		<-events
+	}
+	waitFor(t, "an empty event queue", func() bool { return v.EventQueueDepth() == 0 })
+	for i := range 9 {
+		server.publish("__keyevent@0__:expired", fmt.Sprintf("again%d", i))
+	}
+	waitFor(t, "a second high-water report", func() bool { return recorder.count() == 2 })
+}
+
+func TestListenMultipleDBs(t *testing.T) {
+	v, server := newFakeEventRedisGk(t, RedisAdditionalOptions{EventDBs: []int{0, 2}})
+	defer v.Close()
+
+	events := v.ListenChannelKeyEventManager()
+	server.waitForSubscriber(t, "__keyevent@2__:del")
+	server.waitForSubscriber(t, "__keyevent@0__:del")
+
+	// notify-keyspace-events is server-wide, set once for all databases
+	configs := 0
+	for _, name := range server.receivedCommands() {
+		if name == "config" {
+			configs++
+		}
+	}
+	if configs != 1 {
+		t.Errorf("got %d CONFIG commands, want 1", configs)
+	}
+
+	server.publish("__keyevent@0__:del", "orders:1")
+	server.publish("__keyevent@2__:del", "reports:1")
+
+	got := make(map[string]int)
+	for range 2 {
+		event := waitForEvent(t, events, 5*time.Second, func(KeyEvent) bool { return true })
+		got[event.Key] = event.DB
+	}
+	if want := map[string]int{"orders:1": 0, "reports:1": 2}; !maps.Equal(got, want) {
+		t.Errorf("events by key and DB %v, want %v", got, want)
+	}
+
+	// Databases not listed are not subscribed
+	if n := server.subscribers("__keyevent@1__:del"); n != 0 {
+		t.Errorf("%d subscribers of DB 1 events, want 0", n)
+	}
+}
@@ -0,0 +1,184 @@
+package redisgklib
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// releaseLockScript deletes the lock only if it still holds the owner token
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// extendLockScript resets the lock TTL only if it still holds the owner token
+var extendLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Lock - distributed lock held by this client, created by AcquireLock
+type Lock struct {
+	v     *RedisGk
+	key   string
+	token string
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	released bool
+	stop     context.CancelFunc
+	done     chan struct{}
+}
+
+// AcquireLock takes the lock on the key for ttl (SET NX PX with a random owner token)
+// Returns ErrLockNotAcquired if the lock is held by someone else
+func (v *RedisGk) AcquireLock(keyPath []string, ttl time.Duration) (*Lock, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if ttl < time.Millisecond {
+		return nil, fmt.Errorf("ttl must be >= 1ms, got: %s", ttl)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, fmt.Errorf("error generating lock token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	acquired, err := v.redisClient.SetNX(ctx, keyP, token, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error acquiring lock %s: %w", keyP, err)
+	}
+	if !acquired {
+		return nil, fmt.Errorf("%w: %s", ErrLockNotAcquired, keyP)
+	}
+
+	return &Lock{v: v, key: keyP, token: token, ttl: ttl}, nil
+}
+
+// Release stops KeepAlive and deletes the lock if it is still held by this client
+// Returns ErrLockNotHeld if the lock has expired or was taken by someone else
+func (l *Lock) Release() error {
+	if l == nil || l.v == nil {
+		return fmt.Errorf("lock is nil")
+	}
+
+	l.mu.Lock()
+	if l.released {
+		l.mu.Unlock()
+		return fmt.Errorf("%w: %s already released", ErrLockNotHeld, l.key)
+	}
+	l.released = true
+	stop, done := l.stop, l.done
+	l.mu.Unlock()
+
+	// Wait for KeepAlive, so it can't extend the lock after the release
+	if stop != nil {
+		stop()
+		<-done
+	}
+
+	ctx, cancel := l.v.createContextWithTimeout()
+	defer cancel()
+
+	deleted, err := releaseLockScript.Run(ctx, l.v.redisClient, []string{l.key}, l.token).Int64()
+	if err != nil {
+		return fmt.Errorf("error releasing lock %s: %w", l.key, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: %s", ErrLockNotHeld, l.key)
+	}
+
+	return nil
+}
+
+// KeepAlive extends the lock TTL every interval in a background goroutine, so long
+// critical sections don't outlive the lock. interval should be well below the lock TTL.
+// Stops on Release, when ctx is done or when the lock turns out to be lost (the loss is
+// logged). Calling it again on the same lock has no effect.
+func (l *Lock) KeepAlive(ctx context.Context, interval time.Duration) error {
+	if l == nil || l.v == nil {
+		return fmt.Errorf("lock is nil")
+	}
+	if ctx == nil {
+		return fmt.Errorf("context is nil")
+	}
+	if interval <= 0 || interval >= l.ttl {
+		return fmt.Errorf("interval must be > 0 and < lock ttl %s, got: %s", l.ttl, interval)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.released {
+		return fmt.Errorf("%w: %s already released", ErrLockNotHeld, l.key)
+	}
+	if l.stop != nil {
+		return nil
+	}
+
+	ctx, l.stop = context.WithCancel(ctx)
+	l.done = make(chan struct{})
+
+	go func() {
+		defer close(l.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			held, err := l.extend(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				logf(l.v.logger, ctx, "redisgk: error extending lock %s: %v", l.key, err)
+				continue
+			}
+			if !held {
+				logf(l.v.logger, ctx, "redisgk: lock %s was lost, keepalive stopped", l.key)
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// extend resets the lock TTL, false if the lock is no longer held by this client
+func (l *Lock) extend(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.v.baseCtx)
+	defer cancel()
+
+	extended, err := extendLockScript.Run(ctx, l.v.redisClient, []string{l.key}, l.token, l.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, err
+	}
+	return extended == 1, nil
+}
@@ -0,0 +1,49 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLockKeepAlive(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "job")
+
+	lock, err := v.AcquireLock(key, 500*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lock.KeepAlive(context.Background(), 150*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		exists, err := v.Exists(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Fatal("lock expired while kept alive")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if _, err := v.AcquireLock(key, time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Fatalf("second acquire: got %v, want ErrLockNotAcquired", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Fatalf("release after keepalive: %v", err)
+	}
+
+	// Release stops the keepalive, so the lock is free
+	second, err := v.AcquireLock(key, time.Second)
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	if err := second.Release(); err != nil {
+		t.Fatal(err)
+	}
+}
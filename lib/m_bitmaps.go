@@ -0,0 +1,126 @@
+package redisgklib
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Methods for working with bitmaps and bit fields
+
+// BitField operations
+const (
+	BitFieldGet    = "GET"
+	BitFieldSet    = "SET"
+	BitFieldIncrBy = "INCRBY"
+)
+
+// BitFieldOverflow - behavior of SET and INCRBY on overflow
+type BitFieldOverflow string
+
+const (
+	BitFieldOverflowWrap BitFieldOverflow = "WRAP" // Wrap around (default)
+	BitFieldOverflowSat  BitFieldOverflow = "SAT"  // Saturate at the minimum or maximum value
+	BitFieldOverflowFail BitFieldOverflow = "FAIL" // Don't change the field, BitField returns an error
+)
+
+// BitFieldOp - one operation of BitField
+type BitFieldOp struct {
+	Op       string           // BitFieldGet, BitFieldSet or BitFieldIncrBy
+	Type     string           // Signed or unsigned integer type, e.g. "u8", "i16"
+	Offset   int64            // Offset in bits
+	Indexed  bool             // Offset is multiplied by the type width (#offset)
+	Value    int64            // Value for SET, increment for INCRBY
+	Overflow BitFieldOverflow // Overflow behavior for this and following operations (empty - unchanged)
+}
+
+// args returns BITFIELD arguments of the operation
+func (op BitFieldOp) args() ([]any, error) {
+	if !isValidBitFieldType(op.Type) {
+		return nil, fmt.Errorf("invalid bit field type: %q", op.Type)
+	}
+	if op.Offset < 0 {
+		return nil, fmt.Errorf("offset must be >= 0, got: %d", op.Offset)
+	}
+
+	offset := strconv.FormatInt(op.Offset, 10)
+	if op.Indexed {
+		offset = "#" + offset
+	}
+
+	var args []any
+	switch op.Overflow {
+	case "":
+	case BitFieldOverflowWrap, BitFieldOverflowSat, BitFieldOverflowFail:
+		args = append(args, "OVERFLOW", string(op.Overflow))
+	default:
+		return nil, fmt.Errorf("unknown bit field overflow: %s", op.Overflow)
+	}
+
+	switch op.Op {
+	case BitFieldGet:
+		args = append(args, BitFieldGet, op.Type, offset)
+	case BitFieldSet, BitFieldIncrBy:
+		args = append(args, op.Op, op.Type, offset, op.Value)
+	default:
+		return nil, fmt.Errorf("unknown bit field operation: %q", op.Op)
+	}
+
+	return args, nil
+}
+
+// isValidBitFieldType checks type like i1..i64 or u1..u63
+func isValidBitFieldType(t string) bool {
+	if len(t) < 2 || (t[0] != 'i' && t[0] != 'u') {
+		return false
+	}
+	bits, err := strconv.Atoi(t[1:])
+	if err != nil || bits < 1 {
+		return false
+	}
+	if t[0] == 'u' {
+		return bits <= 63
+	}
+	return bits <= 64
+}
+
+// BitField runs operations on integer fields packed into one string key (BITFIELD)
+// Returns one result per operation: the value for GET, the old value for SET
+// and the new value for INCRBY. An overflow under BitFieldOverflowFail is returned as an error.
+func (v *RedisGk) BitField(keyPath []string, ops ...BitFieldOp) ([]int64, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("no operations provided for BitField")
+	}
+
+	var args []any
+	for i, op := range ops {
+		opArgs, err := op.args()
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		args = append(args, opArgs...)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.BitField(ctx, keyP, args...).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, fmt.Errorf("bit field overflow in key %s", keyP)
+		}
+		return nil, fmt.Errorf("error running bit field operations: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package redisgklib
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBitFieldOverflow(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "counters")
+
+	got, err := v.BitField(key,
+		BitFieldOp{Op: BitFieldSet, Type: "u8", Offset: 0, Value: 250},
+		BitFieldOp{Op: BitFieldIncrBy, Type: "u8", Offset: 0, Value: 10},
+		BitFieldOp{Op: BitFieldGet, Type: "u8", Offset: 0},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SET returns the old value, INCRBY wraps 260 around to 4 by default
+	if want := []int64{0, 4, 4}; !slices.Equal(got, want) {
+		t.Errorf("wrap: got %v, want %v", got, want)
+	}
+
+	got, err = v.BitField(key,
+		BitFieldOp{Op: BitFieldIncrBy, Type: "u8", Offset: 1, Indexed: true, Value: 300, Overflow: BitFieldOverflowSat},
+		BitFieldOp{Op: BitFieldGet, Type: "u8", Offset: 8},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// #1 of u8 is bit offset 8
+	if want := []int64{255, 255}; !slices.Equal(got, want) {
+		t.Errorf("saturate: got %v, want %v", got, want)
+	}
+
+	if _, err := v.BitField(key,
+		BitFieldOp{Op: BitFieldIncrBy, Type: "u8", Offset: 0, Value: 255, Overflow: BitFieldOverflowFail},
+	); err == nil {
+		t.Error("overflow under FAIL returned no error")
+	}
+	if got, err := v.BitField(key, BitFieldOp{Op: BitFieldGet, Type: "u8", Offset: 0}); err != nil || got[0] != 4 {
+		t.Errorf("field changed by a failed INCRBY: got %v, %v", got, err)
+	}
+}
+
+func TestBitFieldOpValidation(t *testing.T) {
+	v := &RedisGk{}
+	invalid := []BitFieldOp{
+		{Op: BitFieldGet, Type: "u64"}, // Redis supports unsigned fields up to u63
+		{Op: BitFieldGet, Type: "i65"},
+		{Op: BitFieldGet, Type: "x8"},
+		{Op: "DECR", Type: "u8"},
+		{Op: BitFieldIncrBy, Type: "u8", Overflow: "CLAMP"},
+	}
+	for _, op := range invalid {
+		if _, err := v.BitField([]string{"k"}, op); err == nil {
+			t.Errorf("operation %+v accepted", op)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package redisgklib
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Methods for working with Bloom filters (RedisBloom module or Redis 8+)
+
+// commandProbe - cached check whether the server supports a command
+// Shared with views, so the server is asked once per instance
+type commandProbe struct {
+	command string
+
+	mu        sync.Mutex
+	checked   bool
+	available bool
+}
+
+// newCommandProbe creates a new probe for the command
+func newCommandProbe(command string) *commandProbe {
+	return &commandProbe{command: command}
+}
+
+// check returns ErrModuleNotLoaded if the server doesn't know the command
+// Errors of the check itself are not cached
+func (p *commandProbe) check(ctx context.Context, v *RedisGk) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.checked {
+		// COMMAND INFO returns nil for unknown commands
+		info, err := v.redisClient.Do(ctx, "COMMAND", "INFO", p.command).Slice()
+		if err != nil {
+			return fmt.Errorf("error checking support of %s: %w", p.command, err)
+		}
+		p.available = len(info) > 0 && info[0] != nil
+		p.checked = true
+	}
+
+	if !p.available {
+		return fmt.Errorf("%w: %s is not supported by the server", ErrModuleNotLoaded, p.command)
+	}
+	return nil
+}
+
+// BFAdd adds the item to the Bloom filter, creating it with default parameters if needed (BF.ADD)
+// Returns false if the item may have been added before
+func (v *RedisGk) BFAdd(keyPath []string, item string) (bool, error) {
+	if v == nil || v.bloomProbe == nil {
+		return false, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if item == "" {
+		return false, fmt.Errorf("item is empty")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return false, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	if err := v.bloomProbe.check(ctx, v); err != nil {
+		return false, err
+	}
+
+	result, err := v.redisClient.BFAdd(ctx, keyP, item).Result()
+	if err != nil {
+		return false, fmt.Errorf("error adding to bloom filter: %w", err)
+	}
+
+	return result, nil
+}
+
+// BFExists checks whether the item may be in the Bloom filter (BF.EXISTS)
+// false means the item was definitely never added, true may be a false positive
+func (v *RedisGk) BFExists(keyPath []string, item string) (bool, error) {
+	if v == nil || v.bloomProbe == nil {
+		return false, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if item == "" {
+		return false, fmt.Errorf("item is empty")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return false, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	if err := v.bloomProbe.check(ctx, v); err != nil {
+		return false, err
+	}
+
+	result, err := v.redisClient.BFExists(ctx, keyP, item).Result()
+	if err != nil {
+		return false, fmt.Errorf("error checking bloom filter: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestBloomFilter(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "seen")
+
+	if _, err := v.BFAdd(key, "id-0"); errors.Is(err, ErrModuleNotLoaded) {
+		t.Skip("Bloom filters are not supported by the server")
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < 1000; i++ {
+		if _, err := v.BFAdd(key, "id-"+strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Added items are always reported, never added ones only as rare false positives
+	for i := range 1000 {
+		if ok, err := v.BFExists(key, "id-"+strconv.Itoa(i)); err != nil || !ok {
+			t.Fatalf("added item id-%d: got %v, %v", i, ok, err)
+		}
+	}
+	falsePositives := 0
+	for i := range 1000 {
+		ok, err := v.BFExists(key, "other-"+strconv.Itoa(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			falsePositives++
+		}
+	}
+	// Default BF.ADD error rate is 1%
+	if falsePositives > 50 {
+		t.Errorf("%d false positives of 1000, want about 1%%", falsePositives)
+	}
+
+	if added, err := v.BFAdd(key, "id-1"); err != nil || added {
+		t.Errorf("BFAdd of an existing item: got %v, %v, want false", added, err)
+	}
+}
+
+func TestBloomFilterModuleNotLoaded(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	probes := 0
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if cmd.Name() != "command" {
+			return false, nil
+		}
+		probes++
+		// COMMAND INFO of an unknown command
+		cmd.(*redis.Cmd).SetVal([]any{nil})
+		return true, nil
+	}
+
+	if _, err := v.BFAdd([]string{"seen"}, "id"); !errors.Is(err, ErrModuleNotLoaded) {
+		t.Errorf("BFAdd: got %v, want ErrModuleNotLoaded", err)
+	}
+	if _, err := v.BFExists([]string{"seen"}, "id"); !errors.Is(err, ErrModuleNotLoaded) {
+		t.Errorf("BFExists: got %v, want ErrModuleNotLoaded", err)
+	}
+	if probes != 1 {
+		t.Errorf("module support checked %d times, want once", probes)
+	}
+}
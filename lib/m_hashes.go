@@ -1,4 +1,533 @@
 package redisgklib
 
-// Reserved for future implementation of methods for working with hashes (Hashes)
-// Planned methods: HSET, HGET, HDEL, HKEYS, HVALS, HGETALL, HINCRBY, HINCRBYFLOAT, etc.
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Methods for working with hashes (Hashes)
+// Planned methods: HDEL, HKEYS, HVALS, HINCRBY, HINCRBYFLOAT, etc.
+
+// SetMap saves map to Redis as a hash, serializing each value to JSON
+// The previous hash is replaced, so fields missing from m are removed
+func SetMap[T any](
+	v *RedisGk,
+	keyPath []string,
+	m map[string]T,
+	ttlSlice ...time.Duration,
+) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if len(m) == 0 {
+		return fmt.Errorf("no fields provided for SetMap")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	fields := make(map[string]any, len(m))
+	for field, value := range m {
+		if field == "" {
+			return fmt.Errorf("empty field name in map")
+		}
+		jsonData, err := v.encodeValue(value)
+		if err != nil {
+			return fmt.Errorf("field %s serialization error: %w", field, err)
+		}
+		if err := v.checkValueSize(jsonData); err != nil {
+			return err
+		}
+		fields[field] = jsonData
+	}
+
+	ttl := v.resolveTTL(ttlSlice)
+
+	_, err = v.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keyP)
+		pipe.HSet(ctx, keyP, fields)
+		if ttl > 0 {
+			pipe.PExpire(ctx, keyP, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error saving map %s: %w", keyP, err)
+	}
+
+	return nil
+}
+
+// GetMap gets all fields of a hash saved by SetMap with JSON deserialization
+// Returns ErrKeyNotFound if the hash doesn't exist
+func GetMap[T any](
+	v *RedisGk,
+	keyPath []string,
+) (map[string]T, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	fields, err := v.redisClient.HGetAll(ctx, keyP).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting map %s: %w", keyP, err)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+	}
+
+	result := make(map[string]T, len(fields))
+	for field, raw := range fields {
+		var value T
+		if err := v.unmarshalValue([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("field %s deserialization error: %w", field, err)
+		}
+		result[field] = value
+	}
+
+	return result, nil
+}
+
+// SetMapField saves one field of a hash with JSON serialization
+// Other fields and the key TTL are left unchanged
+func SetMapField[T any](
+	v *RedisGk,
+	keyPath []string,
+	field string,
+	value T,
+) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if field == "" {
+		return fmt.Errorf("field name is empty")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	jsonData, err := v.encodeValue(value)
+	if err != nil {
+		return fmt.Errorf("field %s serialization error: %w", field, err)
+	}
+	if err := v.checkValueSize(jsonData); err != nil {
+		return err
+	}
+
+	if err := v.redisClient.HSet(ctx, keyP, field, jsonData).Err(); err != nil {
+		return fmt.Errorf("error saving field %s of map %s: %w", field, keyP, err)
+	}
+
+	return nil
+}
+
+// GetMapField gets one field of a hash with JSON deserialization
+// Returns ErrElementNotFound if the field or the hash doesn't exist
+func GetMapField[T any](
+	v *RedisGk,
+	keyPath []string,
+	field string,
+) (*T, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if field == "" {
+		return nil, fmt.Errorf("field name is empty")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	raw, err := v.redisClient.HGet(ctx, keyP, field).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, fmt.Errorf("%w: field %s of %s", ErrElementNotFound, field, keyP)
+		}
+		return nil, fmt.Errorf("error getting field %s of map %s: %w", field, keyP, err)
+	}
+
+	var result T
+	if err := v.unmarshalValue(raw, &result); err != nil {
+		return nil, fmt.Errorf("field %s deserialization error: %w", field, err)
+	}
+
+	return &result, nil
+}
+
+// GetMapFields gets only the requested fields of a hash with JSON deserialization (HMGET)
+// Fields that don't exist are omitted from the result, so wide maps can be read partially
+func GetMapFields[T any](
+	v *RedisGk,
+	keyPath []string,
+	fields ...string,
+) (map[string]T, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields specified for GetMapFields")
+	}
+	for i, field := range fields {
+		if field == "" {
+			return nil, fmt.Errorf("empty field name at index %d", i)
+		}
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	values, err := v.redisClient.HMGet(ctx, keyP, fields...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting fields of map %s: %w", keyP, err)
+	}
+
+	result := make(map[string]T, len(fields))
+	for i, raw := range values {
+		str, ok := raw.(string)
+		if !ok || i >= len(fields) {
+			continue
+		}
+		var value T
+		if err := v.unmarshalValue([]byte(str), &value); err != nil {
+			return nil, fmt.Errorf("field %s deserialization error: %w", fields[i], err)
+		}
+		result[fields[i]] = value
+	}
+
+	return result, nil
+}
+
+// HRandField returns random fields of the hash without changing it (HRANDFIELD, Redis 6.2+)
+// A negative count allows the same field to be returned multiple times. With withValues
+// the result alternates fields and their values as stored, e.g. JSON written by SetMap.
+// An empty or missing hash returns an empty slice
+func (v *RedisGk) HRandField(keyPath []string, count int64, withValues bool) ([]string, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	if !withValues {
+		result, err := v.redisClient.HRandField(ctx, keyP, int(count)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("error getting random fields of map %s: %w", keyP, err)
+		}
+		if result == nil {
+			result = []string{}
+		}
+		return result, nil
+	}
+
+	pairs, err := v.redisClient.HRandFieldWithValues(ctx, keyP, int(count)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting random fields of map %s: %w", keyP, err)
+	}
+
+	result := make([]string, 0, len(pairs)*2)
+	for _, pair := range pairs {
+		result = append(result, pair.Key, pair.Value)
+	}
+
+	return result, nil
+}
+
+// hashStructField - exported struct field stored as a hash field by HSetStruct
+type hashStructField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// hashStructFields lists the fields of a struct type with their hash field names
+// Names come from the redis tag, then the json tag, then the Go field name; "-" skips the field
+func hashStructFields(t reflect.Type) []hashStructField {
+	var fields []hashStructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag, ok := f.Tag.Lookup("redis")
+		if !ok {
+			tag = f.Tag.Get("json")
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, hashStructField{
+			index:     i,
+			name:      name,
+			omitEmpty: slices.Contains(strings.Split(opts, ","), "omitempty"),
+		})
+	}
+	return fields
+}
+
+// structValue returns the struct held by value, dereferencing pointers
+func structValue(value any) (reflect.Value, error) {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, fmt.Errorf("value is a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("value must be a struct, got: %T", value)
+	}
+	if !rv.CanAddr() {
+		// Fields of a struct passed by value must be addressable for pointer receiver methods
+		addressable := reflect.New(rv.Type()).Elem()
+		addressable.Set(rv)
+		rv = addressable
+	}
+	return rv, nil
+}
+
+// encodeHashField converts a struct field to its hash value
+// Strings, numbers, booleans, []byte and encoding.TextMarshaler types (time.Time, ...)
+// are stored as plain text, other types as JSON
+// Methods with a pointer receiver are found too, matching decodeHashField
+func (v *RedisGk) encodeHashField(rv reflect.Value) (string, error) {
+	value := rv.Interface()
+	if rv.CanAddr() {
+		value = rv.Addr().Interface()
+	}
+	if m, ok := value.(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes()), nil
+		}
+	}
+
+	data, err := v.marshalValue(value)
+	return string(data), err
+}
+
+// decodeHashField parses a hash value written by encodeHashField into the struct field
+func (v *RedisGk) decodeHashField(rv reflect.Value, raw string) error {
+	if rv.Kind() == reflect.Pointer {
+		elem := reflect.New(rv.Type().Elem())
+		if err := v.decodeHashField(elem.Elem(), raw); err != nil {
+			return err
+		}
+		rv.Set(elem)
+		return nil
+	}
+
+	if u, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(raw)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes([]byte(raw))
+			return nil
+		}
+	}
+
+	return json.Unmarshal([]byte(raw), rv.Addr().Interface())
+}
+
+// HSetStruct saves the fields of a flat struct as hash fields with one HSET
+// Field names follow redis, then json tags; strings, numbers, booleans and time.Time are
+// stored as plain text, nested values as JSON. Other hash fields and the key TTL are kept,
+// so a struct with omitempty fields updates only the fields that are set. Nil pointers are skipped
+func HSetStruct[T any](
+	v *RedisGk,
+	keyPath []string,
+	value T,
+) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	rv, err := structValue(value)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	fields := make(map[string]any)
+	for _, f := range hashStructFields(rv.Type()) {
+		fv := rv.Field(f.index)
+		if (f.omitEmpty && fv.IsZero()) || (fv.Kind() == reflect.Pointer && fv.IsNil()) {
+			continue
+		}
+		for fv.Kind() == reflect.Pointer {
+			fv = fv.Elem()
+		}
+
+		str, err := v.encodeHashField(fv)
+		if err != nil {
+			return fmt.Errorf("field %s serialization error: %w", f.name, err)
+		}
+		if err := v.checkValueSize([]byte(str)); err != nil {
+			return err
+		}
+		fields[f.name] = str
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("no fields to save for HSetStruct")
+	}
+
+	err = v.redisClient.HSet(ctx, keyP, fields).Err()
+	if err != nil {
+		return fmt.Errorf("error saving struct to map %s: %w", keyP, err)
+	}
+
+	return nil
+}
+
+// HGetStruct reads a struct saved by HSetStruct from all fields of the hash (HGETALL)
+// Struct fields missing from the hash keep zero values, unknown hash fields are ignored.
+// Returns ErrKeyNotFound if the hash doesn't exist and ErrInvalidValue if a field can't be parsed
+func HGetStruct[T any](
+	v *RedisGk,
+	keyPath []string,
+) (*T, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	var result T
+	rv := reflect.ValueOf(&result).Elem()
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("type must be a struct, got: %s", rv.Type())
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	values, err := v.redisClient.HGetAll(ctx, keyP).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting map %s: %w", keyP, err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+	}
+
+	for _, f := range hashStructFields(rv.Type()) {
+		raw, ok := values[f.name]
+		if !ok {
+			continue
+		}
+		if err := v.decodeHashField(rv.Field(f.index), raw); err != nil {
+			return nil, fmt.Errorf("%w: field %s of map %s: %w", ErrInvalidValue, f.name, keyP, err)
+		}
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,242 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// upperText implements encoding.TextMarshaler and TextUnmarshaler with pointer receivers
+type upperText struct{ s string }
+
+func (u *upperText) MarshalText() ([]byte, error) { return []byte(strings.ToUpper(u.s)), nil }
+
+func (u *upperText) UnmarshalText(text []byte) error {
+	u.s = strings.ToLower(string(text))
+	return nil
+}
+
+type hashFieldsStruct struct {
+	Name    string            `redis:"name"`
+	Count   int               `redis:"count"`
+	Created time.Time         `redis:"created"`
+	Code    upperText         `redis:"code"`
+	CodePtr *upperText        `redis:"code_ptr"`
+	Tags    map[string]string `redis:"tags"`
+}
+
+func TestHashFieldRoundTrip(t *testing.T) {
+	v := &RedisGk{}
+	in := hashFieldsStruct{
+		Name:    "alice",
+		Count:   3,
+		Created: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Code:    upperText{s: "abc"},
+		CodePtr: &upperText{s: "xyz"},
+		Tags:    map[string]string{"a": "b"},
+	}
+
+	// Passed by value like HSetStruct does, so fields start out unaddressable
+	rv, err := structValue(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out hashFieldsStruct
+	outRV := reflect.ValueOf(&out).Elem()
+	for _, f := range hashStructFields(rv.Type()) {
+		fv := rv.Field(f.index)
+		for fv.Kind() == reflect.Pointer {
+			fv = fv.Elem()
+		}
+		raw, err := v.encodeHashField(fv)
+		if err != nil {
+			t.Fatalf("encode %s: %v", f.name, err)
+		}
+		if f.name == "code" && raw != "ABC" {
+			t.Fatalf("code encoded as %q, want MarshalText output %q", raw, "ABC")
+		}
+		if err := v.decodeHashField(outRV.Field(f.index), raw); err != nil {
+			t.Fatalf("decode %s from %q: %v", f.name, raw, err)
+		}
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("round trip: got %+v, want %+v", out, in)
+	}
+}
+
+func TestMapFieldUpdate(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "scores")
+
+	type score struct {
+		Points int `json:"points"`
+	}
+	initial := map[string]score{"alice": {10}, "bob": {20}, "carol": {30}}
+	if err := SetMap(v, key, initial, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetMapField(v, key, "bob", score{25}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetMap[score](v, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]score{"alice": {10}, "bob": {25}, "carol": {30}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("map after field update %v, want %v", got, want)
+	}
+
+	bob, err := GetMapField[score](v, key, "bob")
+	if err != nil || bob.Points != 25 {
+		t.Errorf("GetMapField: got %+v, %v", bob, err)
+	}
+	if _, err := GetMapField[score](v, key, "dave"); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("GetMapField of a missing field: got %v, want ErrElementNotFound", err)
+	}
+}
+
+func TestGetMapFieldsProjection(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "product")
+
+	wide := map[string]string{
+		"name":        "Lamp",
+		"price":       "19.99",
+		"description": strings.Repeat("long text ", 100),
+		"reviews":     strings.Repeat("review ", 100),
+	}
+	if err := SetMap(v, key, wide, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetMapFields[string](v, key, "name", "price", "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"name": "Lamp", "price": "19.99"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("projection %v, want %v", got, want)
+	}
+}
+
+func TestHRandField(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "flags")
+	ctx := context.Background()
+
+	flags := map[string]int{"search": 10, "checkout": 50, "profile": 100}
+	if err := SetMap(v, key, flags); err != nil {
+		t.Fatal(err)
+	}
+	keyName := testKeyName(t, v, prefix, "flags")
+	before, err := v.redisClient.HGetAll(ctx, keyName).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields, err := v.HRandField(key, 2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 2 || fields[0] == fields[1] {
+		t.Errorf("count 2: got %q, want 2 distinct fields", fields)
+	}
+	for _, field := range fields {
+		if _, ok := flags[field]; !ok {
+			t.Errorf("unknown field %q", field)
+		}
+	}
+
+	// A count over the size returns every field once, a negative count allows duplicates
+	if fields, err := v.HRandField(key, 10, false); err != nil || len(fields) != 3 {
+		t.Errorf("count 10: got %q, %v, want all 3 fields", fields, err)
+	}
+	if fields, err := v.HRandField(key, -5, false); err != nil || len(fields) != 5 {
+		t.Errorf("count -5: got %q, %v, want 5 fields", fields, err)
+	}
+
+	pairs, err := v.HRandField(key, 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 2 || pairs[1] != strconv.Itoa(flags[pairs[0]]) {
+		t.Errorf("with values: got %q, want a field and its value", pairs)
+	}
+
+	after, err := v.redisClient.HGetAll(ctx, keyName).Result()
+	if err != nil || !reflect.DeepEqual(after, before) {
+		t.Errorf("hash changed to %v, %v, want %v", after, err, before)
+	}
+
+	if fields, err := v.HRandField(testKey(prefix, "missing"), 2, false); err != nil || len(fields) != 0 {
+		t.Errorf("missing hash: got %q, %v, want empty", fields, err)
+	}
+}
+
+func TestHSetStructRoundTrip(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "profile")
+
+	type profile struct {
+		ID      int64             `redis:"id"`
+		Name    string            `json:"display_name"`
+		Score   float64           `redis:"score"`
+		Active  bool              `json:"active"`
+		Joined  time.Time         `redis:"joined"`
+		Labels  map[string]string `redis:"labels"`
+		Nick    string            `json:"nick,omitempty"`
+		Ignored string            `redis:"-"`
+		Plain   string
+	}
+	in := profile{
+		ID:      42,
+		Name:    "Alice",
+		Score:   9.5,
+		Active:  true,
+		Joined:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Labels:  map[string]string{"tier": "gold"},
+		Ignored: "not stored",
+		Plain:   "untagged",
+	}
+	if err := HSetStruct(v, key, in); err != nil {
+		t.Fatal(err)
+	}
+
+	fields, err := v.redisClient.HGetAll(context.Background(), testKeyName(t, v, prefix, "profile")).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"id":           "42",
+		"display_name": "Alice",
+		"score":        "9.5",
+		"active":       "true",
+		"joined":       in.Joined.Format(time.RFC3339Nano),
+		"labels":       `{"tier":"gold"}`,
+		"Plain":        "untagged",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("stored fields %v, want %v", fields, want)
+	}
+
+	got, err := HGetStruct[profile](v, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in.Ignored = ""
+	if !reflect.DeepEqual(*got, in) {
+		t.Errorf("round trip: got %+v, want %+v", *got, in)
+	}
+
+	if _, err := HGetStruct[profile](v, testKey(prefix, "missing")); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("HGetStruct of a missing hash: got %v, want ErrKeyNotFound", err)
+	}
+}
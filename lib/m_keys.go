@@ -0,0 +1,479 @@
+package redisgklib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Methods for working with keys of any type
+
+// Dump returns the key value in the Redis serialization format (DUMP)
+// The result can be restored with Restore on any instance with a compatible Redis version
+func (v *RedisGk) Dump(keyPath []string) ([]byte, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.Dump(ctx, keyP).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+		}
+		return nil, fmt.Errorf("error dumping key %s: %w", keyP, err)
+	}
+
+	return []byte(result), nil
+}
+
+// Restore creates the key from data produced by Dump (RESTORE)
+// ttl 0 - no expiration; replace allows overwriting an existing key
+func (v *RedisGk) Restore(keyPath []string, ttl time.Duration, data []byte, replace bool) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if ttl < 0 {
+		return fmt.Errorf("ttl must be >= 0, got: %s", ttl)
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("no data provided for Restore")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	if replace {
+		err = v.redisClient.RestoreReplace(ctx, keyP, ttl, string(data)).Err()
+	} else {
+		err = v.redisClient.Restore(ctx, keyP, ttl, string(data)).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("error restoring key %s: %w", keyP, err)
+	}
+
+	return nil
+}
+
+// Touch updates the last access time of keys (TOUCH) and returns how many of them exist
+func (v *RedisGk) Touch(keyPaths ...[]string) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if len(keyPaths) == 0 {
+		return 0, fmt.Errorf("no keys specified for Touch")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keys, err := v.convertKeyPaths(keyPaths)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := v.redisClient.Touch(ctx, keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error touching keys: %w", err)
+	}
+
+	return result, nil
+}
+
+// CopyToDB copies the key to dstPath in database destDB of the same server (COPY, Redis 6.2+)
+// The value and TTL are copied; replace allows overwriting an existing destination key.
+// Returns false if the source doesn't exist or the destination exists without replace.
+func (v *RedisGk) CopyToDB(srcPath, dstPath []string, destDB int, replace bool) (bool, error) {
+	if v == nil {
+		return false, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if destDB < 0 {
+		return false, fmt.Errorf("destDB must be >= 0, got: %d", destDB)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	src, err := v.slicePathsConvertor(srcPath)
+	if err != nil {
+		return false, fmt.Errorf("source key conversion error: %w", err)
+	}
+	dst, err := v.slicePathsConvertor(dstPath)
+	if err != nil {
+		return false, fmt.Errorf("destination key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.Copy(ctx, src, dst, destDB, replace).Result()
+	if err != nil {
+		return false, fmt.Errorf("error copying key %s to DB %d: %w", src, destDB, err)
+	}
+
+	return result == 1, nil
+}
+
+// PExpire sets the key TTL with millisecond precision (PEXPIRE)
+// Returns false if the key doesn't exist
+func (v *RedisGk) PExpire(keyPath []string, ttl time.Duration) (bool, error) {
+	if v == nil {
+		return false, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if ttl < time.Millisecond {
+		return false, fmt.Errorf("ttl must be >= 1ms, got: %s", ttl)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return false, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.PExpire(ctx, keyP, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("error setting TTL of key %s: %w", keyP, err)
+	}
+
+	return result, nil
+}
+
+// PExpireAt sets the key to expire at the given time with millisecond precision (PEXPIREAT)
+// A time in the past deletes the key. Returns false if the key doesn't exist.
+func (v *RedisGk) PExpireAt(keyPath []string, t time.Time) (bool, error) {
+	if v == nil {
+		return false, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if t.IsZero() {
+		return false, fmt.Errorf("expiration time is zero")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return false, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.PExpireAt(ctx, keyP, t).Result()
+	if err != nil {
+		return false, fmt.Errorf("error setting expiration time of key %s: %w", keyP, err)
+	}
+
+	return result, nil
+}
+
+// PTTL returns the remaining key TTL with millisecond precision (PTTL)
+// Returns 0 if the key has no expiration and ErrKeyNotFound if it doesn't exist
+func (v *RedisGk) PTTL(keyPath []string) (time.Duration, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.PTTL(ctx, keyP).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error getting TTL of key %s: %w", keyP, err)
+	}
+
+	// Special replies are returned as is: -2 - no key, -1 - no expiration
+	switch result {
+	case -2:
+		return 0, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+	case -1:
+		return 0, nil
+	}
+
+	return result, nil
+}
+
+// InspectMany gets existence, remaining TTL and type of many keys in one pipeline (PTTL, TYPE)
+// The result is keyed by normalized key names; keys that don't exist have Exists = false
+func (v *RedisGk) InspectMany(keyPaths [][]string) (map[string]KeyInfo, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if len(keyPaths) == 0 {
+		return nil, fmt.Errorf("no keys provided for InspectMany")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keys, err := v.convertKeyPaths(keyPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	ttlCmds := make([]*redis.DurationCmd, len(keys))
+	typeCmds := make([]*redis.StatusCmd, len(keys))
+	_, err = v.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			ttlCmds[i] = pipe.PTTL(ctx, key)
+			typeCmds[i] = pipe.Type(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error inspecting keys: %w", err)
+	}
+
+	result := make(map[string]KeyInfo, len(keys))
+	for i, key := range keys {
+		keyType := typeCmds[i].Val()
+		if keyType == "none" {
+			// Deleted between PTTL and TYPE or never existed
+			result[key] = KeyInfo{}
+			continue
+		}
+
+		info := KeyInfo{Exists: true, Type: keyType}
+		// Special replies: -2 - no key, -1 - no expiration
+		if ttl := ttlCmds[i].Val(); ttl > 0 {
+			info.TTL = ttl
+		}
+		result[key] = info
+	}
+
+	return result, nil
+}
+
+// ObjectIdleTime returns how long the key was not accessed (OBJECT IDLETIME, LRU insight)
+// Returns ErrPolicyNotSupported under an LFU maxmemory-policy, which doesn't track idle time
+func (v *RedisGk) ObjectIdleTime(keyPath []string) (time.Duration, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.ObjectIdleTime(ctx, keyP).Result()
+	if err != nil {
+		return 0, objectInfoError(keyP, "idle time", err)
+	}
+
+	return result, nil
+}
+
+// ObjectFreq returns the logarithmic access frequency counter of the key (OBJECT FREQ, LFU insight)
+// Returns ErrPolicyNotSupported unless maxmemory-policy is an LFU policy
+func (v *RedisGk) ObjectFreq(keyPath []string) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.ObjectFreq(ctx, keyP).Result()
+	if err != nil {
+		return 0, objectInfoError(keyP, "access frequency", err)
+	}
+
+	return result, nil
+}
+
+// objectInfoError converts an OBJECT subcommand error
+// Redis replies "ERR An LFU maxmemory policy is (not) selected..." when the metric isn't tracked
+func objectInfoError(key, metric string, err error) error {
+	if err == redis.Nil {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+	if strings.Contains(err.Error(), "maxmemory policy") {
+		return fmt.Errorf("%w: %s is not tracked by maxmemory-policy: %w", ErrPolicyNotSupported, metric, err)
+	}
+	return fmt.Errorf("error getting %s of key %s: %w", metric, key, err)
+}
+
+// WaitForKey blocks until the key exists, polling EXISTS every pollInterval
+// Returns the context error if ctx is done before the key appears
+func (v *RedisGk) WaitForKey(ctx context.Context, keyPath []string, pollInterval time.Duration) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if ctx == nil {
+		return fmt.Errorf("context is nil")
+	}
+	if pollInterval <= 0 {
+		return fmt.Errorf("poll interval must be > 0, got: %s", pollInterval)
+	}
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	// Each check is bounded by BaseCtx and cancelled together with ctx
+	view := v.WithContext(ctx)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		exists, err := view.keyExists(keyP)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// keyExists checks the existence of an already converted key
+func (v *RedisGk) keyExists(key string) (bool, error) {
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	result, err := v.redisClient.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("error checking key existence: %w", err)
+	}
+
+	return result > 0, nil
+}
+
+// Sort returns the elements of the list, set or sorted set in sorted order using SORT
+// The key is not changed. A missing key returns an empty slice; with Get patterns,
+// values of missing keys are returned as empty strings
+func (v *RedisGk) Sort(keyPath []string, opts SortOptions) ([]string, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+	if opts.Offset < 0 || opts.Count < 0 {
+		return nil, fmt.Errorf("offset and count must be >= 0, got: %d, %d", opts.Offset, opts.Count)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	sort := &redis.Sort{
+		By:     opts.By,
+		Offset: opts.Offset,
+		Count:  opts.Count,
+		Get:    opts.Get,
+		Alpha:  opts.Alpha,
+	}
+	if opts.Desc {
+		sort.Order = "DESC"
+	}
+	// LIMIT with count 0 returns nothing, a negative count returns all elements after the offset
+	if opts.Offset > 0 && opts.Count == 0 {
+		sort.Count = -1
+	}
+
+	result, err := v.redisClient.Sort(ctx, keyP, sort).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error sorting %s: %w", keyP, err)
+	}
+
+	return result, nil
+}
+
+// lenScript returns the key type and the size of its value in one atomic call
+// -1 - type without a size command, e.g. a module type
+var lenScript = redis.NewScript(`
+local t = redis.call('TYPE', KEYS[1])['ok']
+if t == 'none' then
+	return {t, 0}
+end
+local commands = {string = 'STRLEN', list = 'LLEN', set = 'SCARD', zset = 'ZCARD', hash = 'HLEN', stream = 'XLEN'}
+local command = commands[t]
+if not command then
+	return {t, -1}
+end
+return {t, redis.call(command, KEYS[1])}
+`)
+
+// Len returns the size of the key value whatever its type: elements of a list, set,
+// sorted set or stream, fields of a hash, or bytes of a string as stored
+// Returns ErrKeyNotFound if the key doesn't exist and ErrWrongType for other types
+func (v *RedisGk) Len(keyPath []string) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := lenScript.Run(ctx, v.redisClient, []string{keyP}).Slice()
+	if err != nil {
+		return 0, fmt.Errorf("error getting length of key %s: %w", keyP, err)
+	}
+	if len(result) != 2 {
+		return 0, fmt.Errorf("unexpected reply getting length of key %s: %v", keyP, result)
+	}
+
+	keyType, _ := result[0].(string)
+	size, ok := result[1].(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected reply getting length of key %s: %v", keyP, result)
+	}
+
+	switch {
+	case keyType == "none":
+		return 0, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+	case size < 0:
+		return 0, fmt.Errorf("%w: %s holds %s, which has no length", ErrWrongType, keyP, keyType)
+	}
+
+	return size, nil
+}
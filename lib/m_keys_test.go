@@ -0,0 +1,371 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestSortDesc(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "ids")
+
+	if err := v.RPush(key, "3", "10", "1", "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := v.Sort(key, SortOptions{Desc: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"10", "3", "2", "1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	got, err = v.Sort(key, SortOptions{Offset: 1, Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2", "3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("with offset and count: got %v, want %v", got, want)
+	}
+}
+
+func TestSortByGet(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "ids")
+	base := testKeyName(t, v, prefix)
+
+	if err := v.RPush(key, "1", "2", "3"); err != nil {
+		t.Fatal(err)
+	}
+	companions := map[string][2]string{
+		"1": {"30", "alice"},
+		"2": {"10", "bob"},
+		"3": {"20", "carol"},
+	}
+	for id, c := range companions {
+		if err := v.SetString(testKey(prefix, "weight_"+id), c[0], time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		if err := v.SetString(testKey(prefix, "name_"+id), c[1], time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := v.Sort(key, SortOptions{
+		By:  base + ":weight_*",
+		Get: []string{"#", base + ":name_*"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2", "bob", "3", "carol", "1", "alice"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestLen(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+
+	if err := v.RPush(testKey(prefix, "list"), "a", "b", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SAdd(testKey(prefix, "set"), "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.ZAdd(testKey(prefix, "zset"), map[string]float64{"a": 1, "b": 2, "c": 3, "d": 4}); err != nil {
+		t.Fatal(err)
+	}
+	type pair struct {
+		A string `redis:"a"`
+		B string `redis:"b"`
+	}
+	if err := HSetStruct(v, testKey(prefix, "hash"), pair{A: "1", B: "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString(testKey(prefix, "string"), "hello", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]int64{"list": 3, "set": 2, "zset": 4, "hash": 2, "string": 5}
+	for name, n := range want {
+		got, err := v.Len(testKey(prefix, name))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got != n {
+			t.Errorf("%s: got %d, want %d", name, got, n)
+		}
+	}
+
+	if _, err := v.Len(testKey(prefix, "missing")); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("missing key: got %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestWaitForKey(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "ready")
+
+	errs := make(chan error, 1)
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		errs <- v.SetString(key, "1", time.Minute)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if err := v.WaitForKey(ctx, key, 20*time.Millisecond); err != nil {
+		t.Fatalf("WaitForKey: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("returned after %s, before the key was created", elapsed)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+
+	short, cancelShort := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancelShort()
+	if err := v.WaitForKey(short, testKey(prefix, "never"), 20*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("missing key: got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestDumpRestoreAcrossInstances(t *testing.T) {
+	source, sourcePrefix := newTestRedisGk(t)
+	target, targetPrefix := newTestRedisGk(t)
+	ctx := context.Background()
+
+	hash := testKeyName(t, source, sourcePrefix, "hash")
+	fields := map[string]string{"name": "Alice", "age": "30"}
+	if err := source.redisClient.HSet(ctx, hash, fields).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := source.Dump(testKey(sourcePrefix, "hash"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := testKey(targetPrefix, "copy")
+	if err := target.Restore(key, time.Minute, data, false); err != nil {
+		t.Fatal(err)
+	}
+	got, err := target.redisClient.HGetAll(ctx, testKeyName(t, target, targetPrefix, "copy")).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, fields) {
+		t.Errorf("restored hash %v, want %v", got, fields)
+	}
+	if ttl, err := target.PTTL(key); err != nil || ttl <= 0 || ttl > time.Minute {
+		t.Errorf("restored TTL %s, %v", ttl, err)
+	}
+
+	// An existing key is replaced only with replace
+	if err := target.Restore(key, 0, data, false); err == nil {
+		t.Error("Restore over an existing key without replace succeeded")
+	}
+	if err := target.Restore(key, 0, data, true); err != nil {
+		t.Errorf("Restore with replace: %v", err)
+	}
+
+	if _, err := source.Dump(testKey(sourcePrefix, "missing")); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Dump of a missing key: got %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestTouch(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+
+	for _, name := range []string{"a", "b"} {
+		if err := v.SetString(testKey(prefix, name), "v", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	touched, err := v.Touch(testKey(prefix, "a"), testKey(prefix, "missing"), testKey(prefix, "b"), testKey(prefix, "other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if touched != 2 {
+		t.Errorf("Touch counted %d keys, want 2 existing ones", touched)
+	}
+
+	if _, err := v.Touch(); err == nil {
+		t.Error("Touch without keys succeeded")
+	}
+}
+
+func TestPExpireAndPTTL(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "job")
+
+	if err := v.SetString(key, "v", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := v.PExpire(key, 1500*time.Millisecond); err != nil || !ok {
+		t.Fatalf("PExpire: got %v, %v", ok, err)
+	}
+	ttl, err := v.PTTL(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl <= 1400*time.Millisecond || ttl > 1500*time.Millisecond {
+		t.Errorf("PTTL %s, want about 1500ms", ttl)
+	}
+
+	at := time.Now().Add(2500 * time.Millisecond)
+	if ok, err := v.PExpireAt(key, at); err != nil || !ok {
+		t.Fatalf("PExpireAt: got %v, %v", ok, err)
+	}
+	if ttl, err := v.PTTL(key); err != nil || ttl <= 2400*time.Millisecond || ttl > 2500*time.Millisecond {
+		t.Errorf("PTTL after PExpireAt %s, %v, want about 2500ms", ttl, err)
+	}
+
+	if ok, err := v.PExpire(testKey(prefix, "missing"), time.Second); err != nil || ok {
+		t.Errorf("PExpire of a missing key: got %v, %v, want false", ok, err)
+	}
+	if _, err := v.PTTL(testKey(prefix, "missing")); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("PTTL of a missing key: got %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestCopyToDB(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	ctx := context.Background()
+
+	src := testKey(prefix, "staging")
+	dst := testKey(prefix, "prod")
+	if err := v.SetString(src, "index"); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := *v.redisClient.Options()
+	opts.DB = 1
+	other := redis.NewClient(&opts)
+	dstName := testKeyName(t, v, prefix, "prod")
+	t.Cleanup(func() {
+		other.Del(ctx, dstName)
+		other.Close()
+	})
+
+	if copied, err := v.CopyToDB(src, dst, 1, false); err != nil || !copied {
+		t.Fatalf("CopyToDB: got %v, %v, want true", copied, err)
+	}
+	if got, err := other.Get(ctx, dstName).Result(); err != nil || got != "index" {
+		t.Errorf("copy in DB 1: got %q, %v, want index", got, err)
+	}
+	if exists, err := v.Exists(dst); err != nil || exists {
+		t.Errorf("copy appeared in the source DB: %v, %v", exists, err)
+	}
+
+	// An existing destination is kept without replace
+	if copied, err := v.CopyToDB(src, dst, 1, false); err != nil || copied {
+		t.Errorf("CopyToDB over an existing key: got %v, %v, want false", copied, err)
+	}
+	if copied, err := v.CopyToDB(src, dst, 1, true); err != nil || !copied {
+		t.Errorf("CopyToDB with replace: got %v, %v, want true", copied, err)
+	}
+
+	if _, err := v.CopyToDB(src, dst, -1, false); err == nil {
+		t.Error("CopyToDB to a negative DB succeeded")
+	}
+}
+
+func TestObjectIdleTime(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "cold")
+
+	if err := v.SetString(key, "v"); err != nil {
+		t.Fatal(err)
+	}
+	first, err := v.ObjectIdleTime(key)
+	if errors.Is(err, ErrPolicyNotSupported) {
+		t.Skip("the server runs an LFU maxmemory-policy")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Idle time has a resolution of one second
+	time.Sleep(2100 * time.Millisecond)
+	second, err := v.ObjectIdleTime(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second <= first {
+		t.Errorf("idle time of an untouched key: %s after %s, want it increased", second, first)
+	}
+
+	if _, err := v.ObjectIdleTime(testKey(prefix, "missing")); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("ObjectIdleTime of a missing key: got %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestObjectFreqWithoutLFUPolicy(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	server.failCommand("object", "ERR An LFU maxmemory policy is not selected, access frequency not tracked. "+
+		"Please note that when switching between policies at runtime LRU and LFU data will take some time to adjust.")
+
+	v, err := NewRedisGk(server.conf("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	if _, err := v.ObjectFreq([]string{"cache", "1"}); !errors.Is(err, ErrPolicyNotSupported) {
+		t.Errorf("ObjectFreq: got %v, want ErrPolicyNotSupported", err)
+	}
+}
+
+func TestInspectMany(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+
+	if err := v.SetString(testKey(prefix, "expiring"), "v", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString(testKey(prefix, "persistent"), "v"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.RPush(testKey(prefix, "list"), "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := [][]string{
+		testKey(prefix, "expiring"),
+		testKey(prefix, "persistent"),
+		testKey(prefix, "list"),
+		testKey(prefix, "missing"),
+	}
+	got, err := v.InspectMany(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(paths) {
+		t.Fatalf("got %d entries, want %d: %v", len(got), len(paths), got)
+	}
+
+	expiring := got[testKeyName(t, v, prefix, "expiring")]
+	if !expiring.Exists || expiring.Type != KeyTypeString || expiring.TTL <= 0 || expiring.TTL > time.Minute {
+		t.Errorf("expiring key: %+v", expiring)
+	}
+	want := map[string]KeyInfo{
+		testKeyName(t, v, prefix, "persistent"): {Exists: true, Type: KeyTypeString},
+		testKeyName(t, v, prefix, "list"):       {Exists: true, Type: KeyTypeList},
+		testKeyName(t, v, prefix, "missing"):    {},
+	}
+	for key, info := range want {
+		if got[key] != info {
+			t.Errorf("%s: got %+v, want %+v", key, got[key], info)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package redisgklib
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -15,7 +17,7 @@ func (v *RedisGk) LPush(keyPath []string, values ...string) error {
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return fmt.Errorf("key conversion error: %w", err)
 	}
@@ -49,7 +51,7 @@ func (v *RedisGk) RPush(keyPath []string, values ...string) error {
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return fmt.Errorf("key conversion error: %w", err)
 	}
@@ -83,7 +85,7 @@ func (v *RedisGk) LPop(keyPath []string) (string, error) {
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return "", fmt.Errorf("key conversion error: %w", err)
 	}
@@ -108,7 +110,7 @@ func (v *RedisGk) RPop(keyPath []string) (string, error) {
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return "", fmt.Errorf("key conversion error: %w", err)
 	}
@@ -124,7 +126,43 @@ func (v *RedisGk) RPop(keyPath []string) (string, error) {
 	return result, nil
 }
 
-// LRange returns list elements in the specified range
+// LMPop pops up to count elements from the head of the first non-empty list (LMPOP, Redis 7.0+)
+// Lists are checked in the order given. Returns the key the elements were popped from,
+// or ErrListsEmpty if all lists are empty.
+func (v *RedisGk) LMPop(count int64, keyPaths ...[]string) (string, []string, error) {
+	if v == nil {
+		return "", nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if count <= 0 {
+		return "", nil, fmt.Errorf("count must be > 0, got: %d", count)
+	}
+	if len(keyPaths) == 0 {
+		return "", nil, fmt.Errorf("no keys specified for LMPop")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keys, err := v.convertKeyPaths(keyPaths)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key, values, err := v.redisClient.LMPop(ctx, "left", count, keys...).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", nil, ErrListsEmpty
+		}
+		return "", nil, fmt.Errorf("error popping from lists: %w", err)
+	}
+
+	return key, values, nil
+}
+
+// LRange returns list elements in the specified range, both ends inclusive
+// Negative indices count from the end: -1 is the last element, -2 the one before it.
+// Out of range indices are clamped, so LRange(key, 0, -1) returns the whole list.
 func (v *RedisGk) LRange(keyPath []string, start, stop int64) ([]string, error) {
 	if v == nil {
 		return nil, fmt.Errorf("RedisGk instance is nil")
@@ -133,7 +171,7 @@ func (v *RedisGk) LRange(keyPath []string, start, stop int64) ([]string, error)
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return nil, fmt.Errorf("key conversion error: %w", err)
 	}
@@ -146,6 +184,28 @@ func (v *RedisGk) LRange(keyPath []string, start, stop int64) ([]string, error)
 	return result, nil
 }
 
+// LFirst returns the first n elements of the list (fewer if the list is shorter)
+func (v *RedisGk) LFirst(keyPath []string, n int64) ([]string, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be > 0, got: %d", n)
+	}
+	return v.LRange(keyPath, 0, n-1)
+}
+
+// LLast returns the last n elements of the list in list order (fewer if the list is shorter)
+func (v *RedisGk) LLast(keyPath []string, n int64) ([]string, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be > 0, got: %d", n)
+	}
+	return v.LRange(keyPath, -n, -1)
+}
+
 // LLen returns the length of the list
 func (v *RedisGk) LLen(keyPath []string) (int64, error) {
 	if v == nil {
@@ -155,7 +215,7 @@ func (v *RedisGk) LLen(keyPath []string) (int64, error) {
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return 0, fmt.Errorf("key conversion error: %w", err)
 	}
@@ -167,3 +227,449 @@ func (v *RedisGk) LLen(keyPath []string) (int64, error) {
 
 	return result, nil
 }
+
+// lrangeAndDelScript reads the whole list and deletes it in one atomic step
+var lrangeAndDelScript = redis.NewScript(`
+local items = redis.call('LRANGE', KEYS[1], 0, -1)
+redis.call('DEL', KEYS[1])
+return items
+`)
+
+// LRangeAndDel atomically returns all list elements and deletes the list
+// Elements pushed concurrently end up either in the result or in a new list, never in both
+// A missing list returns an empty slice
+func (v *RedisGk) LRangeAndDel(keyPath []string) ([]string, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := lrangeAndDelScript.Run(ctx, v.redisClient, []string{keyP}).StringSlice()
+	if err != nil {
+		if err == redis.Nil {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("error draining list: %w", err)
+	}
+
+	if result == nil {
+		result = []string{}
+	}
+
+	return result, nil
+}
+
+// rotateListScript renames the list to the archive key unless the archive exists
+// Returns 0 for a missing source, 1 when rotated and -1 if the archive exists
+var rotateListScript = redis.NewScript(`
+local t = redis.call('TYPE', KEYS[1])['ok']
+if t == 'none' then
+	return 0
+end
+if t ~= 'list' then
+	return redis.error_reply('WRONGTYPE Operation against a key holding the wrong kind of value')
+end
+if redis.call('RENAMENX', KEYS[1], KEYS[2]) == 0 then
+	return -1
+end
+return 1
+`)
+
+// RotateList atomically moves the list to the archive key, e.g. for log rotation
+// Elements pushed after the rotation start a new list at the source key, so none are lost.
+// A missing source is not an error, there is nothing to rotate. Returns ErrKeyExists if the
+// archive key exists, so an earlier archive is never overwritten.
+func (v *RedisGk) RotateList(srcPath, archivePath []string) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	srcP, err := v.slicePathsConvertor(srcPath)
+	if err != nil {
+		return fmt.Errorf("source key conversion error: %w", err)
+	}
+	archiveP, err := v.slicePathsConvertor(archivePath)
+	if err != nil {
+		return fmt.Errorf("archive key conversion error: %w", err)
+	}
+	if srcP == archiveP {
+		return fmt.Errorf("source and archive keys are the same: %s", srcP)
+	}
+
+	result, err := rotateListScript.Run(ctx, v.redisClient, []string{srcP, archiveP}).Int()
+	if err != nil {
+		return fmt.Errorf("error rotating list %s: %w", srcP, err)
+	}
+
+	if result == -1 {
+		return fmt.Errorf("%w: %s", ErrKeyExists, archiveP)
+	}
+
+	return nil
+}
+
+// uniqueIDsKeyPrefix - namespace of companion sets of RPushUnique
+const uniqueIDsKeyPrefix = "__redisgk_ids:"
+
+// rpushUniqueScript pushes the value only if the id is new to the companion set
+var rpushUniqueScript = redis.NewScript(`
+if redis.call('SADD', KEYS[2], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('RPUSH', KEYS[1], ARGV[2])
+return 1
+`)
+
+// RPushUnique adds the value to the end of the list only if id was not pushed before
+// Seen ids are kept in the companion set __redisgk_ids:<key>, which is not deleted
+// with the list; delete it with Del when the ids may be reused, or with CleanupOrphans.
+// Returns whether the value was pushed.
+func (v *RedisGk) RPushUnique(keyPath []string, id, value string) (bool, error) {
+	if v == nil {
+		return false, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if id == "" {
+		return false, fmt.Errorf("id is empty")
+	}
+	if value == "" {
+		return false, fmt.Errorf("value is empty")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return false, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	pushed, err := rpushUniqueScript.Run(ctx, v.redisClient, []string{keyP, uniqueIDsKeyPrefix + keyP}, id, value).Int64()
+	if err != nil {
+		return false, fmt.Errorf("error adding to list: %w", err)
+	}
+
+	return pushed == 1, nil
+}
+
+// LPushObj adds objects to the beginning of the list with automatic JSON serialization
+func LPushObj[T any](v *RedisGk, keyPath []string, items ...T) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	if len(items) == 0 {
+		return fmt.Errorf("no values provided for LPushObj")
+	}
+
+	values, err := marshalListItems(v, items)
+	if err != nil {
+		return err
+	}
+
+	_, err = v.redisClient.LPush(ctx, keyP, values...).Result()
+	if err != nil {
+		return fmt.Errorf("error adding to list: %w", err)
+	}
+
+	return nil
+}
+
+// RPushObjBatch adds many objects to the end of the list with automatic JSON serialization,
+// sending them in chunks of batchSize values per RPUSH within one pipeline (batchSize <= 0 - 1000)
+func RPushObjBatch[T any](v *RedisGk, keyPath []string, items []T, batchSize int) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if len(items) == 0 {
+		return fmt.Errorf("no values provided for RPushObjBatch")
+	}
+
+	values, err := marshalListItems(v, items)
+	if err != nil {
+		return err
+	}
+
+	return v.pushChunks(keyPath, values, batchSize, false)
+}
+
+// marshalListItems serializes list and set objects to JSON, compressing large ones
+// Raw list methods reject empty strings, but a JSON value is never empty (an empty
+// string is stored as ""), so objects are only checked to be non-nil instead
+func marshalListItems[T any](v *RedisGk, items []T) ([]any, error) {
+	values := make([]any, 0, len(items))
+	for i, item := range items {
+		jsonData, err := v.marshalValue(item)
+		if err != nil {
+			return nil, fmt.Errorf("object serialization error at index %d: %w", i, err)
+		}
+		if string(jsonData) == "null" {
+			return nil, fmt.Errorf("%w: nil object at index %d", ErrInvalidValue, i)
+		}
+		jsonData, err = v.encodeMarshaled(item, jsonData)
+		if err != nil {
+			return nil, err
+		}
+		if err := v.checkValueSize(jsonData); err != nil {
+			return nil, err
+		}
+		values = append(values, jsonData)
+	}
+	return values, nil
+}
+
+// LRangeObj returns list objects in the specified range with automatic JSON deserialization
+func LRangeObj[T any](v *RedisGk, keyPath []string, start, stop int64) ([]T, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	items, err := v.redisClient.LRange(ctx, keyP, start, stop).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting list elements: %w", err)
+	}
+
+	result := make([]T, 0, len(items))
+	for i, item := range items {
+		var obj T
+		if err := v.unmarshalValue([]byte(item), &obj); err != nil {
+			return nil, fmt.Errorf("object deserialization error at index %d: %w", i, err)
+		}
+		result = append(result, obj)
+	}
+
+	return result, nil
+}
+
+// LMove atomically moves an element from one end of the source list to one end of the destination list
+// srcEnd and dstEnd are ListEndLeft or ListEndRight
+func (v *RedisGk) LMove(srcPath, dstPath []string, srcEnd, dstEnd string) (string, error) {
+	if v == nil {
+		return "", fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	srcP, dstP, srcEnd, dstEnd, err := v.prepareListMove(srcPath, dstPath, srcEnd, dstEnd)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := v.redisClient.LMove(ctx, srcP, dstP, srcEnd, dstEnd).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", fmt.Errorf("list is empty: %s", srcP)
+		}
+		return "", fmt.Errorf("error moving list element: %w", err)
+	}
+
+	return result, nil
+}
+
+// BLMove is the blocking variant of LMove, waiting up to timeout for an element
+// A zero timeout blocks until an element is available or the base context expires
+func (v *RedisGk) BLMove(srcPath, dstPath []string, srcEnd, dstEnd string, timeout time.Duration) (string, error) {
+	if v == nil {
+		return "", fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if timeout < 0 {
+		return "", fmt.Errorf("timeout must be >= 0, got: %s", timeout)
+	}
+
+	ctx, cancel := v.createContextWithExtraTimeout(timeout)
+	defer cancel()
+
+	srcP, dstP, srcEnd, dstEnd, err := v.prepareListMove(srcPath, dstPath, srcEnd, dstEnd)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := v.redisClient.BLMove(ctx, srcP, dstP, srcEnd, dstEnd, timeout).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", fmt.Errorf("list is empty: %s", srcP)
+		}
+		return "", fmt.Errorf("error moving list element: %w", err)
+	}
+
+	return result, nil
+}
+
+// prepareListMove converts list paths and validates list ends for LMove and BLMove
+func (v *RedisGk) prepareListMove(srcPath, dstPath []string, srcEnd, dstEnd string) (string, string, string, string, error) {
+	srcP, err := v.slicePathsConvertor(srcPath)
+	if err != nil {
+		return "", "", "", "", fmt.Errorf("source key conversion error: %w", err)
+	}
+
+	dstP, err := v.slicePathsConvertor(dstPath)
+	if err != nil {
+		return "", "", "", "", fmt.Errorf("destination key conversion error: %w", err)
+	}
+
+	srcEnd = strings.ToUpper(srcEnd)
+	if srcEnd != ListEndLeft && srcEnd != ListEndRight {
+		return "", "", "", "", fmt.Errorf("invalid source list end: %s", srcEnd)
+	}
+
+	dstEnd = strings.ToUpper(dstEnd)
+	if dstEnd != ListEndLeft && dstEnd != ListEndRight {
+		return "", "", "", "", fmt.Errorf("invalid destination list end: %s", dstEnd)
+	}
+
+	return srcP, dstP, srcEnd, dstEnd, nil
+}
+
+// LPos returns index of the element in the list
+// rank selects the n-th match, negative rank searches from the end (0 - first match)
+// Returns ErrElementNotFound if the element is not present
+func (v *RedisGk) LPos(keyPath []string, value string, rank int64) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.LPos(ctx, keyP, value, redis.LPosArgs{Rank: rank}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, fmt.Errorf("%w: %s in list %s", ErrElementNotFound, value, keyP)
+		}
+		return 0, fmt.Errorf("error getting element position: %w", err)
+	}
+
+	return result, nil
+}
+
+// LPosCount returns indexes of up to count matches of the element in the list (0 - all matches)
+// Returns ErrElementNotFound if the element is not present
+func (v *RedisGk) LPosCount(keyPath []string, value string, rank int64, count int64) ([]int64, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if count < 0 {
+		return nil, fmt.Errorf("count must be >= 0, got: %d", count)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.LPosCount(ctx, keyP, value, count, redis.LPosArgs{Rank: rank}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("error getting element positions: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%w: %s in list %s", ErrElementNotFound, value, keyP)
+	}
+
+	return result, nil
+}
+
+// RPushBatch adds many elements to the end of the list, sending them in chunks
+// of batchSize values per RPUSH within one pipeline (batchSize <= 0 - 1000)
+func (v *RedisGk) RPushBatch(keyPath []string, values []string, batchSize int) error {
+	return v.pushBatch(keyPath, values, batchSize, false)
+}
+
+// LPushBatch adds many elements to the beginning of the list, sending them in chunks
+// of batchSize values per LPUSH within one pipeline (batchSize <= 0 - 1000)
+func (v *RedisGk) LPushBatch(keyPath []string, values []string, batchSize int) error {
+	return v.pushBatch(keyPath, values, batchSize, true)
+}
+
+// pushBatch validates raw values and pipelines chunked LPUSH or RPUSH commands
+func (v *RedisGk) pushBatch(keyPath []string, values []string, batchSize int, left bool) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	// Check for empty values
+	if len(values) == 0 {
+		return fmt.Errorf("no values provided for batch push")
+	}
+
+	// Check for empty strings in values
+	args := make([]any, 0, len(values))
+	for i, value := range values {
+		if value == "" {
+			return fmt.Errorf("empty value at index %d", i)
+		}
+		args = append(args, value)
+	}
+
+	return v.pushChunks(keyPath, args, batchSize, left)
+}
+
+// pushChunks pipelines chunked LPUSH or RPUSH commands
+func (v *RedisGk) pushChunks(keyPath []string, values []any, batchSize int, left bool) error {
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	_, err = v.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for start := 0; start < len(values); start += batchSize {
+			end := min(start+batchSize, len(values))
+			if left {
+				pipe.LPush(ctx, keyP, values[start:end]...)
+			} else {
+				pipe.RPush(ctx, keyP, values[start:end]...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error adding to list: %w", err)
+	}
+
+	return nil
+}
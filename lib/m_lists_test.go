@@ -0,0 +1,407 @@
+package redisgklib
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type listItem struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+func TestLPushObjLRangeObj(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "queue")
+
+	base := time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	items := []listItem{{1, base}, {2, base.Add(time.Hour)}, {3, base.Add(2 * time.Hour)}}
+	if err := LPushObj(v, key, items...); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LRangeObj[listItem](v, key, 0, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// LPUSH puts each item at the head, so they come back reversed
+	want := []listItem{items[2], items[1], items[0]}
+	if len(got) != len(want) {
+		t.Fatalf("got %d items, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || !got[i].Created.Equal(want[i].Created) {
+			t.Errorf("item %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	empty, err := LRangeObj[listItem](v, testKey(prefix, "missing"), 0, -1)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("missing list: got %v, %v, want no items", empty, err)
+	}
+}
+
+func TestLMove(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	src, dst := testKey(prefix, "src"), testKey(prefix, "dst")
+
+	if err := v.RPush(src, "a", "b", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.RPush(dst, "x"); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := v.LMove(src, dst, ListEndRight, ListEndLeft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved != "c" {
+		t.Errorf("moved %q, want %q", moved, "c")
+	}
+
+	for key, want := range map[string][]string{"src": {"a", "b"}, "dst": {"c", "x"}} {
+		got, err := v.LRange(testKey(prefix, key), 0, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", key, got, want)
+		}
+	}
+
+	if _, err := v.LMove(src, dst, "up", ListEndLeft); err == nil {
+		t.Error("invalid list end accepted")
+	}
+	if _, err := v.LMove(testKey(prefix, "empty"), dst, ListEndLeft, ListEndLeft); err == nil {
+		t.Error("moving from an empty list succeeded")
+	}
+}
+
+func TestLPos(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "queue")
+
+	// Indexes:               0    1    2    3    4    5
+	if err := v.RPush(key, "a", "b", "c", "b", "d", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	positions := map[int64]int64{0: 1, 1: 1, 2: 3, -1: 5, -2: 3}
+	for rank, want := range positions {
+		got, err := v.LPos(key, "b", rank)
+		if err != nil {
+			t.Fatalf("rank %d: %v", rank, err)
+		}
+		if got != want {
+			t.Errorf("rank %d: got %d, want %d", rank, got, want)
+		}
+	}
+
+	all, err := v.LPosCount(key, "b", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(all, []int64{1, 3, 5}) {
+		t.Errorf("all matches: got %v, want [1 3 5]", all)
+	}
+	two, err := v.LPosCount(key, "b", -1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(two, []int64{5, 3}) {
+		t.Errorf("two matches from the end: got %v, want [5 3]", two)
+	}
+
+	if _, err := v.LPos(key, "z", 0); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("LPos of a missing element: got %v, want ErrElementNotFound", err)
+	}
+	if _, err := v.LPosCount(key, "z", 0, 0); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("LPosCount of a missing element: got %v, want ErrElementNotFound", err)
+	}
+}
+
+const benchmarkListSize = 10000
+
+func benchmarkListValues() []string {
+	values := make([]string, benchmarkListSize)
+	for i := range values {
+		values[i] = fmt.Sprintf("value_%d", i)
+	}
+	return values
+}
+
+func BenchmarkRPushIndividual(b *testing.B) {
+	v, prefix := newTestRedisGk(b)
+	values := benchmarkListValues()
+
+	b.ResetTimer()
+	for i := range b.N {
+		key := testKey(prefix, "list", strconv.Itoa(i))
+		for _, value := range values {
+			if err := v.RPush(key, value); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkRPushBatch(b *testing.B) {
+	v, prefix := newTestRedisGk(b)
+	values := benchmarkListValues()
+
+	b.ResetTimer()
+	for i := range b.N {
+		if err := v.RPushBatch(testKey(prefix, "list", strconv.Itoa(i)), values, 1000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestLRangeAndDelConcurrentProducer(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "queue")
+
+	const total = 1000
+	done := make(chan error)
+	go func() {
+		for i := range total {
+			if err := v.RPush(key, strconv.Itoa(i)); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	seen := make(map[string]int)
+	drain := func() {
+		items, err := v.LRangeAndDel(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range items {
+			seen[item]++
+		}
+	}
+
+	for producing := true; producing; {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+			producing = false
+		default:
+		}
+		drain()
+	}
+	drain()
+
+	if len(seen) != total {
+		t.Errorf("drained %d distinct elements, want %d", len(seen), total)
+	}
+	for item, n := range seen {
+		if n != 1 {
+			t.Errorf("element %s drained %d times", item, n)
+		}
+	}
+	if n, err := v.Len(key); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("list left with %d elements, %v", n, err)
+	}
+}
+
+func TestLMPop(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	first, second, third := testKey(prefix, "p0"), testKey(prefix, "p1"), testKey(prefix, "p2")
+
+	if err := v.RPush(second, "a", "b", "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	key, values, err := v.LMPop(2, first, second, third)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := testKeyName(t, v, prefix, "p1"); key != want {
+		t.Errorf("popped from %s, want %s", key, want)
+	}
+	if !slices.Equal(values, []string{"a", "b"}) {
+		t.Errorf("popped %q, want [a b]", values)
+	}
+
+	if _, _, err := v.LMPop(5, first, second, third); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := v.LMPop(1, first, second, third); !errors.Is(err, ErrListsEmpty) {
+		t.Errorf("LMPop of empty lists: got %v, want ErrListsEmpty", err)
+	}
+}
+
+func TestLFirstAndLLast(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "log")
+
+	if err := v.RPush(key, "a", "b", "c", "d"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := v.LLast(key, 2); err != nil || !slices.Equal(got, []string{"c", "d"}) {
+		t.Errorf("LLast(2): got %q, %v, want [c d]", got, err)
+	}
+	if got, err := v.LFirst(key, 2); err != nil || !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("LFirst(2): got %q, %v, want [a b]", got, err)
+	}
+	if got, err := v.LLast(key, 10); err != nil || len(got) != 4 {
+		t.Errorf("LLast longer than the list: got %q, %v", got, err)
+	}
+	if got, err := v.LRange(key, -3, -2); err != nil || !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("LRange(-3, -2): got %q, %v, want [b c]", got, err)
+	}
+	if _, err := v.LLast(key, 0); err == nil {
+		t.Error("LLast(0) succeeded")
+	}
+}
+
+func TestRPushUnique(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "ingest")
+
+	keyP, err := v.slicePathsConvertor(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The companion set is outside the test prefix
+	t.Cleanup(func() {
+		ctx, cancel := v.createContextWithTimeout()
+		defer cancel()
+		v.redisClient.Del(ctx, uniqueIDsKeyPrefix+keyP)
+	})
+
+	if pushed, err := v.RPushUnique(key, "msg-1", "first"); err != nil || !pushed {
+		t.Fatalf("first push: got %v, %v, want true", pushed, err)
+	}
+	// A redelivery of the same ID is a no-op
+	if pushed, err := v.RPushUnique(key, "msg-1", "retry"); err != nil || pushed {
+		t.Fatalf("second push: got %v, %v, want false", pushed, err)
+	}
+	if pushed, err := v.RPushUnique(key, "msg-2", "second"); err != nil || !pushed {
+		t.Fatalf("new ID: got %v, %v, want true", pushed, err)
+	}
+
+	if got, err := v.LRange(key, 0, -1); err != nil || !slices.Equal(got, []string{"first", "second"}) {
+		t.Errorf("list: got %q, %v, want [first second]", got, err)
+	}
+}
+
+func TestRPushObjBatchShortValues(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "short")
+
+	// Marshal to "" and {}, accepted although raw empty strings are not
+	if err := RPushObjBatch(v, key, []string{"", "a"}, 0); err != nil {
+		t.Fatalf("RPushObjBatch of an empty string: %v", err)
+	}
+	type empty struct{}
+	if err := LPushObj(v, testKey(prefix, "structs"), empty{}); err != nil {
+		t.Fatalf("LPushObj of an empty struct: %v", err)
+	}
+
+	got, err := LRangeObj[string](v, key, 0, -1)
+	if err != nil || !slices.Equal(got, []string{"", "a"}) {
+		t.Errorf("list: got %q, %v, want [\"\" a]", got, err)
+	}
+
+	if err := RPushObjBatch(v, key, []*listItem{nil}, 0); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RPushObjBatch of a nil object: got %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestCompressedListAndSetObjects(t *testing.T) {
+	v, prefix := newTestRedisGk(t, RedisAdditionalOptions{CompressionThreshold: 256})
+	listKey := testKey(prefix, "list")
+	setKey := testKey(prefix, "set")
+
+	type document struct {
+		ID   int    `json:"id"`
+		Body string `json:"body"`
+	}
+	large := document{ID: 1, Body: strings.Repeat("lorem ipsum ", 100)}
+	small := document{ID: 2, Body: "short"}
+
+	if err := LPushObj(v, listKey, small, large); err != nil {
+		t.Fatal(err)
+	}
+	if err := SAddObj(v, setKey, large); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := v.LRange(listKey, 0, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != 2 || !strings.HasPrefix(raw[0], compressedPrefix) || strings.HasPrefix(raw[1], compressedPrefix) {
+		t.Errorf("stored list %.40q, want only the large object compressed", raw)
+	}
+	if len(raw[0]) >= len(large.Body) {
+		t.Errorf("compressed object takes %d bytes", len(raw[0]))
+	}
+
+	items, err := LRangeObj[document](v, listKey, 0, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0] != large || items[1] != small {
+		t.Errorf("list read back %.60v", items)
+	}
+
+	members, err := SMembersObj[document](v, setKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0] != large {
+		t.Errorf("set read back %.60v", members)
+	}
+}
+
+func TestRotateList(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	src := testKey(prefix, "log")
+	archive := testKey(prefix, "log", "1")
+
+	if err := v.RPush(src, "a", "b", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.RotateList(src, archive); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := v.LRange(archive, 0, -1); err != nil || !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("archive: got %q, %v, want [a b c]", got, err)
+	}
+	if exists, err := v.Exists(src); err != nil || exists {
+		t.Errorf("source exists after rotation: %v, %v", exists, err)
+	}
+
+	// New writes start a fresh list, an earlier archive is never overwritten
+	if err := v.RPush(src, "d"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.RotateList(src, archive); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("rotation onto an existing archive: got %v, want ErrKeyExists", err)
+	}
+	if got, err := v.LRange(src, 0, -1); err != nil || !slices.Equal(got, []string{"d"}) {
+		t.Errorf("source after a failed rotation: got %q, %v, want [d]", got, err)
+	}
+
+	// Nothing to rotate
+	if err := v.RotateList(testKey(prefix, "empty"), testKey(prefix, "empty", "1")); err != nil {
+		t.Errorf("rotation of a missing list: %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package redisgklib
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Methods for storing booleans and numbers as strings
+
+// SetBool saves boolean as "true" or "false"
+func (v *RedisGk) SetBool(keyPath []string, value bool, ttlSlice ...time.Duration) error {
+	return v.SetString(keyPath, strconv.FormatBool(value), ttlSlice...)
+}
+
+// GetBool gets boolean saved by SetBool
+// Returns ErrInvalidValue if the stored value is not a boolean
+func (v *RedisGk) GetBool(keyPath []string) (bool, error) {
+	str, err := v.GetString(keyPath)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := strconv.ParseBool(str)
+	if err != nil {
+		return false, fmt.Errorf("%w: %q is not a boolean: %w", ErrInvalidValue, str, err)
+	}
+
+	return result, nil
+}
+
+// SetInt saves integer in decimal form, compatible with INCR/DECR
+// The value is stored unencrypted also with an Encryptor, so INCR and CompareAndSetInt work
+func (v *RedisGk) SetInt(keyPath []string, value int64, ttlSlice ...time.Duration) error {
+	return v.plainView().SetString(keyPath, strconv.FormatInt(value, 10), ttlSlice...)
+}
+
+// GetInt64 gets integer saved by SetInt or changed by INCR/DECR
+// Returns ErrInvalidValue if the stored value is not an integer
+func (v *RedisGk) GetInt64(keyPath []string) (int64, error) {
+	str, err := v.GetString(keyPath)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not an integer: %w", ErrInvalidValue, str, err)
+	}
+
+	return result, nil
+}
+
+// compareAndSetIntScript replaces the integer only if it equals the expected one, keeping the TTL
+// Integers are compared as decimal strings, Lua numbers can't hold every int64
+var compareAndSetIntScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current then
+	return 0
+end
+if not string.match(current, '^-?%d+$') then
+	return -1
+end
+if current ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'KEEPTTL')
+return 1
+`)
+
+// CompareAndSetInt atomically sets the integer to newValue only if it currently equals expected
+// Returns whether the value was replaced; the TTL is kept. A missing key never matches,
+// create it with SetInt first. Returns ErrInvalidValue if the stored value is not an integer
+func (v *RedisGk) CompareAndSetInt(keyPath []string, expected, newValue int64) (bool, error) {
+	if v == nil {
+		return false, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return false, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := compareAndSetIntScript.Run(ctx, v.redisClient, []string{keyP},
+		strconv.FormatInt(expected, 10), strconv.FormatInt(newValue, 10)).Int()
+	if err != nil {
+		return false, fmt.Errorf("error comparing and setting integer %s: %w", keyP, err)
+	}
+
+	if result == -1 {
+		return false, fmt.Errorf("%w: %s is not an integer", ErrInvalidValue, keyP)
+	}
+
+	return result == 1, nil
+}
+
+// SetFloat saves float in the shortest exact decimal form, compatible with INCRBYFLOAT
+// The value is stored unencrypted also with an Encryptor, so INCRBYFLOAT works
+func (v *RedisGk) SetFloat(keyPath []string, value float64, ttlSlice ...time.Duration) error {
+	return v.plainView().SetString(keyPath, strconv.FormatFloat(value, 'f', -1, 64), ttlSlice...)
+}
+
+// GetFloat64 gets float saved by SetFloat or changed by INCRBYFLOAT
+// Returns ErrInvalidValue if the stored value is not a number
+func (v *RedisGk) GetFloat64(keyPath []string) (float64, error) {
+	str, err := v.GetString(keyPath)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a number: %w", ErrInvalidValue, str, err)
+	}
+
+	return result, nil
+}
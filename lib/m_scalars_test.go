@@ -0,0 +1,144 @@
+package redisgklib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareAndSetInt(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "state")
+
+	if err := v.SetInt(key, 5, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	swapped, err := v.CompareAndSetInt(key, 5, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !swapped {
+		t.Fatal("expected value matched, but the value was not swapped")
+	}
+	if got, err := v.GetInt64(key); err != nil || got != 6 {
+		t.Fatalf("after swap: got %d, %v, want 6", got, err)
+	}
+
+	swapped, err = v.CompareAndSetInt(key, 5, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if swapped {
+		t.Fatal("expected value didn't match, but the value was swapped")
+	}
+	if got, err := v.GetInt64(key); err != nil || got != 6 {
+		t.Fatalf("after no-op: got %d, %v, want 6", got, err)
+	}
+
+	if swapped, err := v.CompareAndSetInt(testKey(prefix, "missing"), 0, 1); err != nil || swapped {
+		t.Fatalf("missing key: got %v, %v, want false, nil", swapped, err)
+	}
+
+	if err := v.SetString(testKey(prefix, "text"), "abc", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.CompareAndSetInt(testKey(prefix, "text"), 0, 1); !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("non-integer value: got %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestScalarRoundTrips(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	if err := v.SetBool([]string{"flag"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := v.GetBool([]string{"flag"}); err != nil || !got {
+		t.Errorf("GetBool: got %v, %v, want true", got, err)
+	}
+
+	if err := v.SetInt([]string{"count"}, -9007199254740993); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := v.GetInt64([]string{"count"}); err != nil || got != -9007199254740993 {
+		t.Errorf("GetInt64: got %d, %v", got, err)
+	}
+
+	if err := v.SetFloat([]string{"ratio"}, 0.1); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := fake.get("ratio"); stored != "0.1" {
+		t.Errorf("SetFloat stored %q, want the shortest form %q", stored, "0.1")
+	}
+	if got, err := v.GetFloat64([]string{"ratio"}); err != nil || got != 0.1 {
+		t.Errorf("GetFloat64: got %v, %v, want 0.1", got, err)
+	}
+}
+
+func TestScalarParseErrors(t *testing.T) {
+	v, _ := newFakeRedisGk(t)
+
+	if err := v.SetString([]string{"word"}, "twelve"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := v.GetInt64([]string{"word"})
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("GetInt64 of a non-numeric value: got %v, want ErrInvalidValue", err)
+	}
+	if !strings.Contains(err.Error(), `"twelve" is not an integer`) {
+		t.Errorf("error %q doesn't name the stored value", err)
+	}
+	if _, err := v.GetBool([]string{"word"}); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("GetBool: got %v, want ErrInvalidValue", err)
+	}
+	if _, err := v.GetFloat64([]string{"word"}); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("GetFloat64: got %v, want ErrInvalidValue", err)
+	}
+	if _, err := v.GetInt64([]string{"missing"}); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetInt64 of a missing key: got %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestNumbersStayPlainWithEncryptor(t *testing.T) {
+	encryptor, err := NewAESGCMEncryptor(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, prefix := newTestRedisGk(t, RedisAdditionalOptions{Encryptor: encryptor})
+	counter := testKey(prefix, "counter")
+	ratio := testKey(prefix, "ratio")
+	ctx := context.Background()
+
+	if err := v.SetInt(counter, 5); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := v.redisClient.Incr(ctx, testKeyName(t, v, prefix, "counter")).Result(); err != nil || n != 6 {
+		t.Errorf("INCR: got %d, %v, want 6", n, err)
+	}
+	if swapped, err := v.CompareAndSetInt(counter, 6, 10); err != nil || !swapped {
+		t.Errorf("CompareAndSetInt: got %v, %v, want true", swapped, err)
+	}
+	if n, err := v.GetInt64(counter); err != nil || n != 10 {
+		t.Errorf("GetInt64: got %d, %v, want 10", n, err)
+	}
+
+	if err := v.SetFloat(ratio, 1.5); err != nil {
+		t.Fatal(err)
+	}
+	if f, err := v.redisClient.IncrByFloat(ctx, testKeyName(t, v, prefix, "ratio"), 0.25).Result(); err != nil || f != 1.75 {
+		t.Errorf("INCRBYFLOAT: got %v, %v, want 1.75", f, err)
+	}
+
+	// Other strings are still encrypted
+	if err := v.SetString(testKey(prefix, "note"), "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if raw, err := v.GetRawString(testKey(prefix, "note")); err != nil || !strings.HasPrefix(raw, encryptedPrefix) {
+		t.Errorf("SetString stored %q, %v, want ciphertext", raw, err)
+	}
+}
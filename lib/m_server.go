@@ -0,0 +1,169 @@
+package redisgklib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Methods for working with server state and replication
+
+// WaitForReplicas blocks until previous writes of the current connection are
+// acknowledged by numReplicas replicas or the timeout is reached.
+// Returns the number of replicas that acknowledged the writes.
+// A zero timeout waits until the base context expires.
+// WAIT tracks writes per connection, so with a connection pool it may run on a
+// different connection than the write; use a pool size of 1 or GetRedisClient().Conn()
+// (not available in StrictMode)
+// when the guarantee must cover one specific write.
+func (v *RedisGk) WaitForReplicas(numReplicas int, timeout time.Duration) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if numReplicas < 0 {
+		return 0, fmt.Errorf("numReplicas must be >= 0, got: %d", numReplicas)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("timeout must be >= 0, got: %s", timeout)
+	}
+
+	ctx, cancel := v.createContextWithExtraTimeout(timeout)
+	defer cancel()
+
+	result, err := v.redisClient.Wait(ctx, numReplicas, timeout).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error waiting for replicas: %w", err)
+	}
+
+	return result, nil
+}
+
+// ReplicationInfo returns the master replication offset and offsets acknowledged by
+// connected replicas, parsed from INFO replication
+// The difference between them shows how many bytes of writes a replica is behind.
+// On a replica instance replicaOffsets is empty.
+func (v *RedisGk) ReplicationInfo() (int64, []int64, error) {
+	if v == nil {
+		return 0, nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	info, err := v.redisClient.Info(ctx, "replication").Result()
+	if err != nil {
+		return 0, nil, fmt.Errorf("error getting replication info: %w", err)
+	}
+
+	return parseReplicationInfo(info)
+}
+
+// parseReplicationInfo extracts master_repl_offset and slaveN offsets from INFO replication output
+func parseReplicationInfo(info string) (int64, []int64, error) {
+	var masterOffset int64
+	foundMaster := false
+	replicaOffsets := []int64{}
+
+	for _, line := range strings.Split(info, "\n") {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "master_repl_offset":
+			offset, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return 0, nil, fmt.Errorf("invalid master_repl_offset %q: %w", value, err)
+			}
+			masterOffset = offset
+			foundMaster = true
+		case strings.HasPrefix(name, "slave"):
+			// slave0:ip=10.0.0.2,port=6379,state=online,offset=1234,lag=0
+			if _, err := strconv.Atoi(strings.TrimPrefix(name, "slave")); err != nil {
+				continue
+			}
+			for _, field := range strings.Split(value, ",") {
+				if offsetValue, ok := strings.CutPrefix(field, "offset="); ok {
+					offset, err := strconv.ParseInt(offsetValue, 10, 64)
+					if err != nil {
+						return 0, nil, fmt.Errorf("invalid offset of %s %q: %w", name, offsetValue, err)
+					}
+					replicaOffsets = append(replicaOffsets, offset)
+				}
+			}
+		}
+	}
+
+	if !foundMaster {
+		return 0, nil, fmt.Errorf("master_repl_offset not found in replication info")
+	}
+
+	return masterOffset, replicaOffsets, nil
+}
+
+// MaxMemoryInfo returns the memory limit (0 - no limit), used memory in bytes and the
+// eviction policy, parsed from INFO memory
+// With the noeviction policy writes fail with ErrOutOfMemory once used reaches maxMemory.
+func (v *RedisGk) MaxMemoryInfo() (int64, int64, string, error) {
+	if v == nil {
+		return 0, 0, "", fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	info, err := v.redisClient.Info(ctx, "memory").Result()
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("error getting memory info: %w", err)
+	}
+
+	fields := parseInfoFields(info)
+
+	maxMemory, err := strconv.ParseInt(fields["maxmemory"], 10, 64)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("invalid maxmemory %q: %w", fields["maxmemory"], err)
+	}
+	used, err := strconv.ParseInt(fields["used_memory"], 10, 64)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("invalid used_memory %q: %w", fields["used_memory"], err)
+	}
+
+	return maxMemory, used, fields["maxmemory_policy"], nil
+}
+
+// ServerTime returns the current time of the Redis server (TIME) in UTC
+// Workers sharing one Redis can use it as a common clock instead of their own, possibly
+// skewed clocks. The result is late by the network round trip of the call.
+func (v *RedisGk) ServerTime() (time.Time, error) {
+	if v == nil {
+		return time.Time{}, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	result, err := v.redisClient.Time(ctx).Result()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error getting server time: %w", err)
+	}
+
+	return result.UTC(), nil
+}
+
+// parseInfoFields parses name:value lines of INFO output, skipping section headers
+func parseInfoFields(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			fields[name] = value
+		}
+	}
+	return fields
+}
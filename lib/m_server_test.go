@@ -0,0 +1,119 @@
+package redisgklib
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestParseReplicationInfo(t *testing.T) {
+	info := "# Replication\r\n" +
+		"role:master\r\n" +
+		"connected_slaves:2\r\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=1200,lag=0\r\n" +
+		"slave1:ip=10.0.0.3,port=6379,state=online,offset=1150,lag=1\r\n" +
+		"slave_read_repl_offset:1\r\n" +
+		"master_failover_state:no-failover\r\n" +
+		"master_repl_offset:1234\r\n" +
+		"repl_backlog_active:1\r\n"
+
+	master, replicas, err := parseReplicationInfo(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if master != 1234 {
+		t.Errorf("master offset: got %d, want 1234", master)
+	}
+	if want := []int64{1200, 1150}; !reflect.DeepEqual(replicas, want) {
+		t.Errorf("replica offsets: got %v, want %v", replicas, want)
+	}
+}
+
+func TestParseReplicationInfoErrors(t *testing.T) {
+	cases := map[string]string{
+		"missing master offset": "role:master\r\nconnected_slaves:0\r\n",
+		"invalid master offset": "master_repl_offset:abc\r\n",
+		"invalid replica offset": "master_repl_offset:10\r\n" +
+			"slave0:ip=10.0.0.2,port=6379,state=online,offset=x,lag=0\r\n",
+	}
+	for name, info := range cases {
+		if _, _, err := parseReplicationInfo(info); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	master, replicas, err := parseReplicationInfo("role:slave\r\nmaster_repl_offset:77\r\n")
+	if err != nil || master != 77 || len(replicas) != 0 {
+		t.Errorf("replica without replicas: got %d, %v, %v", master, replicas, err)
+	}
+}
+
+func TestWaitForReplicas(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	// One connected replica: WAIT returns at once when it is enough, otherwise after the timeout
+	var gotTimeout string
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		args := cmd.Args()
+		if cmd.Name() != "wait" || len(args) != 3 {
+			return false, nil
+		}
+		gotTimeout = argString(args[2])
+		if argString(args[1]) != "0" && argString(args[1]) != "1" {
+			ms, _ := strconv.Atoi(gotTimeout)
+			select {
+			case <-time.After(time.Duration(ms) * time.Millisecond):
+			case <-ctx.Done():
+				return true, ctx.Err()
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(1)
+		return true, nil
+	}
+
+	acked, err := v.WaitForReplicas(1, time.Second)
+	if err != nil || acked != 1 {
+		t.Fatalf("one replica: got %d, %v, want 1", acked, err)
+	}
+	if gotTimeout != "1000" {
+		t.Errorf("WAIT timeout argument %q, want 1000", gotTimeout)
+	}
+
+	start := time.Now()
+	acked, err = v.WaitForReplicas(2, 100*time.Millisecond)
+	if err != nil || acked != 1 {
+		t.Fatalf("two replicas: got %d, %v, want 1 after the timeout", acked, err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("returned after %s, before the WAIT timeout", elapsed)
+	}
+
+	if _, err := v.WaitForReplicas(-1, time.Second); err == nil {
+		t.Error("negative numReplicas accepted")
+	}
+	if _, err := v.WaitForReplicas(1, -time.Second); err == nil {
+		t.Error("negative timeout accepted")
+	}
+}
+
+func TestServerTime(t *testing.T) {
+	v, _ := newTestRedisGk(t)
+
+	before := time.Now()
+	got, err := v.ServerTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Allow for clock skew between the test host and the server
+	const delta = 5 * time.Second
+	if got.Before(before.Add(-delta)) || got.After(time.Now().Add(delta)) {
+		t.Errorf("server time %s is more than %s away from the local clock %s", got, delta, before)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("server time in %s, want UTC", got.Location())
+	}
+}
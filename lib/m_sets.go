@@ -1,4 +1,226 @@
 package redisgklib
 
-// Reserved for future implementation of methods for working with sets (Sets)
-// Planned methods: SADD, SREM, SISMEMBER, SMEMBERS, SCARD, SPOP, SRANDMEMBER, etc.
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Methods for working with sets (Sets)
+// Planned methods: SREM, SISMEMBER, SMEMBERS, etc.
+
+// SAdd adds members to the set
+func (v *RedisGk) SAdd(keyPath []string, members ...string) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	// Check for empty members
+	if len(members) == 0 {
+		return fmt.Errorf("no members provided for SAdd")
+	}
+
+	// Check for empty strings in members
+	for i, member := range members {
+		if member == "" {
+			return fmt.Errorf("empty member at index %d", i)
+		}
+	}
+
+	_, err = v.redisClient.SAdd(ctx, keyP, members).Result()
+	if err != nil {
+		return fmt.Errorf("error adding to set: %w", err)
+	}
+
+	return nil
+}
+
+// SCard returns the number of members in the set
+func (v *RedisGk) SCard(keyPath []string) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.SCard(ctx, keyP).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error getting set size: %w", err)
+	}
+
+	return result, nil
+}
+
+// SRandMember returns random members of the set without removing them
+// A negative count allows the same member to be returned multiple times
+// An empty or missing set returns an empty slice
+func (v *RedisGk) SRandMember(keyPath []string, count int64) ([]string, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.SRandMemberN(ctx, keyP, count).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("error getting random set members: %w", err)
+	}
+
+	if result == nil {
+		result = []string{}
+	}
+
+	return result, nil
+}
+
+// SPop removes and returns random members of the set
+// An empty or missing set returns an empty slice
+func (v *RedisGk) SPop(keyPath []string, count int64) ([]string, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be > 0, got: %d", count)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.SPopN(ctx, keyP, count).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("error popping set members: %w", err)
+	}
+
+	if result == nil {
+		result = []string{}
+	}
+
+	return result, nil
+}
+
+// SInterCard returns the number of members in the intersection of sets without
+// transferring them (SINTERCARD, Redis 7.0+)
+// Counting stops at limit (0 - no limit)
+func (v *RedisGk) SInterCard(limit int64, keyPaths ...[]string) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if limit < 0 {
+		return 0, fmt.Errorf("limit must be >= 0, got: %d", limit)
+	}
+	if len(keyPaths) == 0 {
+		return 0, fmt.Errorf("no keys specified for SInterCard")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keys, err := v.convertKeyPaths(keyPaths)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := v.redisClient.SInterCard(ctx, limit, keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error counting set intersection: %w", err)
+	}
+
+	return result, nil
+}
+
+// SAddObj adds objects to the set with automatic JSON serialization
+// Members are compared by their serialized form, so equal objects are stored once
+func SAddObj[T any](v *RedisGk, keyPath []string, items ...T) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	if len(items) == 0 {
+		return fmt.Errorf("no members provided for SAddObj")
+	}
+
+	members, err := marshalListItems(v, items)
+	if err != nil {
+		return err
+	}
+
+	_, err = v.redisClient.SAdd(ctx, keyP, members...).Result()
+	if err != nil {
+		return fmt.Errorf("error adding to set: %w", err)
+	}
+
+	return nil
+}
+
+// SMembersObj returns all objects of the set with automatic JSON deserialization
+// The order of members is not defined; a missing set returns an empty slice
+func SMembersObj[T any](v *RedisGk, keyPath []string) ([]T, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	members, err := v.redisClient.SMembers(ctx, keyP).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting set members: %w", err)
+	}
+
+	result := make([]T, 0, len(members))
+	for i, member := range members {
+		var obj T
+		if err := v.unmarshalValue([]byte(member), &obj); err != nil {
+			return nil, fmt.Errorf("object deserialization error at index %d: %w", i, err)
+		}
+		result = append(result, obj)
+	}
+
+	return result, nil
+}
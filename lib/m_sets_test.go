@@ -0,0 +1,69 @@
+package redisgklib
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSRandMemberAndSPop(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "bucket")
+	members := []string{"a", "b", "c", "d", "e"}
+
+	if err := v.SAdd(key, members...); err != nil {
+		t.Fatal(err)
+	}
+
+	sample, err := v.SRandMember(key, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sample) != 3 {
+		t.Fatalf("got %d members, want 3", len(sample))
+	}
+	for _, m := range sample {
+		if !slices.Contains(members, m) {
+			t.Errorf("sampled %q, which is not a member", m)
+		}
+	}
+	if n, err := v.SCard(key); err != nil || n != int64(len(members)) {
+		t.Fatalf("SRandMember changed the set: SCARD %d, %v", n, err)
+	}
+
+	popped, err := v.SPop(key, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(popped) != 2 {
+		t.Fatalf("popped %d members, want 2", len(popped))
+	}
+	if n, err := v.SCard(key); err != nil || n != 3 {
+		t.Fatalf("after SPop: SCARD %d, %v, want 3", n, err)
+	}
+
+	for _, sample := range []func([]string, int64) ([]string, error){v.SRandMember, v.SPop} {
+		got, err := sample(testKey(prefix, "missing"), 2)
+		if err != nil || len(got) != 0 {
+			t.Errorf("empty set: got %v, %v, want an empty slice", got, err)
+		}
+	}
+}
+
+func TestSInterCard(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	a, b := testKey(prefix, "a"), testKey(prefix, "b")
+
+	if err := v.SAdd(a, "1", "2", "3", "4"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SAdd(b, "3", "4", "5"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := v.SInterCard(0, a, b); err != nil || n != 2 {
+		t.Errorf("SInterCard: got %d, %v, want 2", n, err)
+	}
+	if n, err := v.SInterCard(1, a, b); err != nil || n != 1 {
+		t.Errorf("SInterCard with limit 1: got %d, %v, want 1", n, err)
+	}
+}
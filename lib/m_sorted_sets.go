@@ -1,4 +1,104 @@
 package redisgklib
 
-// Reserved for future implementation of methods for working with sorted sets (Sorted Sets)
-// Planned methods: ZADD, ZREM, ZRANGE, ZRANGEBYSCORE, ZCARD, ZCOUNT, ZRANK, ZREVRANK, ZREVRANGE, ZREVRANGEBYSCORE, etc.
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Methods for working with sorted sets (Sorted Sets)
+// Planned methods: ZREM, ZRANGE, ZRANGEBYSCORE, ZCARD, ZCOUNT, ZRANK, ZREVRANK, ZREVRANGE, ZREVRANGEBYSCORE, etc.
+
+// ZInterCard returns the number of members in the intersection of sorted sets without
+// transferring them (ZINTERCARD, Redis 7.0+)
+// Counting stops at limit (0 - no limit)
+func (v *RedisGk) ZInterCard(limit int64, keyPaths ...[]string) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if limit < 0 {
+		return 0, fmt.Errorf("limit must be >= 0, got: %d", limit)
+	}
+	if len(keyPaths) == 0 {
+		return 0, fmt.Errorf("no keys specified for ZInterCard")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keys, err := v.convertKeyPaths(keyPaths)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := v.redisClient.ZInterCard(ctx, limit, keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error counting sorted set intersection: %w", err)
+	}
+
+	return result, nil
+}
+
+// ZAdd adds members with scores to the sorted set or updates their scores
+// Returns the number of added or updated members
+func (v *RedisGk) ZAdd(keyPath []string, members map[string]float64) (int64, error) {
+	return v.zAdd(keyPath, members, redis.ZAddArgs{}, "ZAdd")
+}
+
+// ZAddGT works like ZAdd, but updates existing members only if the new score is greater
+// New members are always added
+func (v *RedisGk) ZAddGT(keyPath []string, members map[string]float64) (int64, error) {
+	return v.zAdd(keyPath, members, redis.ZAddArgs{GT: true}, "ZAddGT")
+}
+
+// ZAddLT works like ZAdd, but updates existing members only if the new score is less
+// New members are always added
+func (v *RedisGk) ZAddLT(keyPath []string, members map[string]float64) (int64, error) {
+	return v.zAdd(keyPath, members, redis.ZAddArgs{LT: true}, "ZAddLT")
+}
+
+// ZAddNX works like ZAdd, but only adds new members and never updates existing ones
+func (v *RedisGk) ZAddNX(keyPath []string, members map[string]float64) (int64, error) {
+	return v.zAdd(keyPath, members, redis.ZAddArgs{NX: true}, "ZAddNX")
+}
+
+// ZAddXX works like ZAdd, but only updates existing members and never adds new ones
+func (v *RedisGk) ZAddXX(keyPath []string, members map[string]float64) (int64, error) {
+	return v.zAdd(keyPath, members, redis.ZAddArgs{XX: true}, "ZAddXX")
+}
+
+// zAdd runs ZADD with the given modifiers, counting changed members (CH)
+func (v *RedisGk) zAdd(keyPath []string, members map[string]float64, args redis.ZAddArgs, name string) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if len(members) == 0 {
+		return 0, fmt.Errorf("no members provided for %s", name)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	args.Ch = true
+	args.Members = make([]redis.Z, 0, len(members))
+	for member, score := range members {
+		if member == "" {
+			return 0, fmt.Errorf("empty member in %s", name)
+		}
+		args.Members = append(args.Members, redis.Z{Member: member, Score: score})
+	}
+
+	result, err := v.redisClient.ZAddArgs(ctx, keyP, args).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error adding to sorted set: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package redisgklib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestZInterCard(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	a, b := testKey(prefix, "a"), testKey(prefix, "b")
+
+	if _, err := v.ZAdd(a, map[string]float64{"x": 1, "y": 2, "z": 3}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.ZAdd(b, map[string]float64{"y": 10, "z": 20, "w": 30}); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := v.ZInterCard(0, a, b); err != nil || n != 2 {
+		t.Errorf("ZInterCard: got %d, %v, want 2", n, err)
+	}
+}
+
+func TestZAddFlags(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "leaderboard")
+	ctx := context.Background()
+	keyName := testKeyName(t, v, prefix, "leaderboard")
+
+	if _, err := v.ZAdd(key, map[string]float64{"alice": 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A lower score doesn't replace a higher one under GT, new members are added
+	if _, err := v.ZAddGT(key, map[string]float64{"alice": 50, "bob": 70}); err != nil {
+		t.Fatal(err)
+	}
+	assertScore := func(member string, want float64) {
+		t.Helper()
+		score, err := v.redisClient.ZScore(ctx, keyName, member).Result()
+		if err != nil || score != want {
+			t.Errorf("score of %s: got %v, %v, want %v", member, score, err, want)
+		}
+	}
+	assertScore("alice", 100)
+	assertScore("bob", 70)
+
+	if _, err := v.ZAddGT(key, map[string]float64{"alice": 120}); err != nil {
+		t.Fatal(err)
+	}
+	assertScore("alice", 120)
+
+	if _, err := v.ZAddLT(key, map[string]float64{"alice": 130, "bob": 60}); err != nil {
+		t.Fatal(err)
+	}
+	assertScore("alice", 120)
+	assertScore("bob", 60)
+
+	// NX only adds, XX only updates
+	if _, err := v.ZAddNX(key, map[string]float64{"alice": 1, "carol": 10}); err != nil {
+		t.Fatal(err)
+	}
+	assertScore("alice", 120)
+	assertScore("carol", 10)
+	if _, err := v.ZAddXX(key, map[string]float64{"carol": 15, "dave": 5}); err != nil {
+		t.Fatal(err)
+	}
+	assertScore("carol", 15)
+	if err := v.redisClient.ZScore(ctx, keyName, "dave").Err(); err != redis.Nil {
+		t.Errorf("ZAddXX added a new member: %v", err)
+	}
+}
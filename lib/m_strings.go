@@ -1,9 +1,10 @@
 package redisgklib
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -23,27 +24,24 @@ func SetObj[T any](
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return fmt.Errorf("key conversion error: %w", err)
 	}
 
-	jsonData, err := json.Marshal(value)
+	jsonData, err := v.encodeValue(value)
 	if err != nil {
 		return fmt.Errorf("object serialization error: %w", err)
 	}
 
-	err = checkMaxSizeData(jsonData)
+	err = v.checkValueSize(jsonData)
 	if err != nil {
 		return err
 	}
 
-	ttl := time.Duration(0)
-	if len(ttlSlice) > 0 {
-		ttl = ttlSlice[0]
-	}
+	ttl := v.resolveTTL(ttlSlice)
 
-	return v.redisClient.Set(ctx, keyP, jsonData, ttl).Err()
+	return v.storeValue(ctx, keyP, jsonData, ttl)
 }
 
 // SetString saves string to Redis
@@ -59,7 +57,7 @@ func (v *RedisGk) SetString(
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return fmt.Errorf("key conversion error: %w", err)
 	}
@@ -69,17 +67,64 @@ func (v *RedisGk) SetString(
 		return err
 	}
 
-	// Check value size
-	if len(value) > maxSizeData {
-		return fmt.Errorf("value size (%d bytes) exceeds Redis limit (512 MB)", len(value))
+	ttl := v.resolveTTL(ttlSlice)
+
+	// The limit applies to the ciphertext, which can't be truncated
+	if v.encryptor != nil {
+		data, err := v.encryptValue([]byte(value))
+		if err != nil {
+			return err
+		}
+		if err := v.checkValueSize(data); err != nil {
+			return err
+		}
+		return v.storeValue(ctx, keyP, data, ttl)
+	}
+
+	// Check value size, truncating it if the OnOversize policy allows
+	value, err = v.fitStringValue(ctx, keyP, value)
+	if err != nil {
+		return err
+	}
+
+	return v.storeValue(ctx, keyP, value, ttl)
+}
+
+// SetBytes saves binary data to Redis
+// Data over CompressionThreshold is compressed like objects, WithCompression overrides it per call.
+// With an Encryptor the data is encrypted after compression.
+// Data starting with a reserved prefix (~gz:, ~enc:, ~raw:) is stored escaped with ~raw:,
+// GetBytes removes the escape
+func (v *RedisGk) SetBytes(
+	keyPath []string,
+	value []byte,
+	ttlSlice ...time.Duration,
+) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	data, err := v.sealValue(escapeRawValue(value))
+	if err != nil {
+		return err
 	}
 
-	ttl := time.Duration(0)
-	if len(ttlSlice) > 0 {
-		ttl = ttlSlice[0]
+	err = v.checkValueSize(data)
+	if err != nil {
+		return err
 	}
 
-	return v.redisClient.Set(ctx, keyP, value, ttl).Err()
+	ttl := v.resolveTTL(ttlSlice)
+
+	return v.storeValue(ctx, keyP, data, ttl)
 }
 
 // GetObj gets object from Redis with automatic JSON deserialization
@@ -94,7 +139,7 @@ func GetObj[T any](
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return nil, fmt.Errorf("key conversion error: %w", err)
 	}
@@ -102,13 +147,13 @@ func GetObj[T any](
 	jsonStr, err := v.redisClient.Get(ctx, keyP).Result()
 	if err != nil {
 		if err == redis.Nil {
-			return nil, fmt.Errorf("key not found: %s", keyP)
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
 		}
 		return nil, fmt.Errorf("error getting key %s: %w", keyP, err)
 	}
 
 	var result T
-	err = json.Unmarshal([]byte(jsonStr), &result)
+	err = v.unmarshalValue([]byte(jsonStr), &result)
 	if err != nil {
 		return nil, fmt.Errorf("object deserialization error: %w", err)
 	}
@@ -116,6 +161,218 @@ func GetObj[T any](
 	return &result, nil
 }
 
+// GetObjOrNil gets object like GetObj, but returns (nil, nil) if the key doesn't exist
+// Other errors, including deserialization errors, are returned as is
+func GetObjOrNil[T any](
+	v *RedisGk,
+	keyPath []string,
+) (*T, error) {
+	result, err := GetObj[T](v, keyPath)
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetObjWithMigration gets object like GetObj, but when deserialization fails
+// passes the stored data through migrate and retries with its result.
+// This allows reading payloads written by an older version of T.
+func GetObjWithMigration[T any](
+	v *RedisGk,
+	keyPath []string,
+	migrate MigrateFunc,
+) (*T, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if migrate == nil {
+		return nil, fmt.Errorf("migrate function is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	jsonStr, err := v.redisClient.Get(ctx, keyP).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+		}
+		return nil, fmt.Errorf("error getting key %s: %w", keyP, err)
+	}
+
+	// The migration gets plain JSON also for a compressed or encrypted value
+	data, err := v.openValue([]byte(jsonStr))
+	if err != nil {
+		return nil, fmt.Errorf("object deserialization error: %w", err)
+	}
+
+	var result T
+	decodeErr := v.unmarshalValue(data, &result)
+	if decodeErr == nil {
+		return &result, nil
+	}
+
+	migrated, err := migrate(data)
+	if err != nil {
+		return nil, fmt.Errorf("object migration error: %w (deserialization error: %v)", err, decodeErr)
+	}
+
+	result = *new(T)
+	err = v.unmarshalValue(migrated, &result)
+	if err != nil {
+		return nil, fmt.Errorf("object deserialization error after migration: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetDelObj atomically gets object and deletes the key with GETDEL (Redis 6.2+)
+// Returns ErrKeyNotFound if the key is absent, so only one of concurrent callers gets the object
+func GetDelObj[T any](
+	v *RedisGk,
+	keyPath []string,
+) (*T, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	jsonStr, err := v.redisClient.GetDel(ctx, keyP).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+		}
+		return nil, fmt.Errorf("error getting and deleting key %s: %w", keyP, err)
+	}
+
+	var result T
+	err = v.unmarshalValue([]byte(jsonStr), &result)
+	if err != nil {
+		return nil, fmt.Errorf("object deserialization error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetObjWithTTL gets object like GetObj together with its remaining TTL
+// GET and PTTL run in one MULTI/EXEC, so the TTL belongs to the returned value.
+// The TTL is 0 if the key has no expiration, as in PTTL
+func GetObjWithTTL[T any](
+	v *RedisGk,
+	keyPath []string,
+) (*T, time.Duration, error) {
+	if v == nil {
+		return nil, 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	var getCmd *redis.StringCmd
+	var ttlCmd *redis.DurationCmd
+	_, err = v.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		getCmd = pipe.Get(ctx, keyP)
+		ttlCmd = pipe.PTTL(ctx, keyP)
+		return nil
+	})
+	if getCmd != nil && getCmd.Err() == redis.Nil {
+		return nil, 0, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting key %s with TTL: %w", keyP, err)
+	}
+
+	var result T
+	err = v.unmarshalValue([]byte(getCmd.Val()), &result)
+	if err != nil {
+		return nil, 0, fmt.Errorf("object deserialization error: %w", err)
+	}
+
+	// -1 - no expiration
+	ttl := ttlCmd.Val()
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	return &result, ttl, nil
+}
+
+// GetObjMany gets objects for an explicit list of keys with one MGET
+// Returns found objects by normalized key and the list of missing keys
+// Objects with deserialization errors are skipped, as in FindObj
+func GetObjMany[T any](
+	v *RedisGk,
+	keyPaths [][]string,
+) (map[string]*T, []string, error) {
+	if v == nil {
+		return nil, nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if len(keyPaths) == 0 {
+		return nil, nil, fmt.Errorf("no keys specified")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keys, err := v.convertKeyPaths(keyPaths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := v.mget(ctx, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := make(map[string]*T)
+	var missing []string
+
+	for i, value := range values {
+		if value == nil {
+			missing = append(missing, keys[i])
+			continue
+		}
+
+		jsonStr, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var obj T
+		if err := v.unmarshalValue([]byte(jsonStr), &obj); err != nil {
+			// Skip objects with deserialization errors
+			continue
+		}
+
+		results[keys[i]] = &obj
+	}
+
+	return results, missing, nil
+}
+
 // GetString gets string from Redis
 func (v *RedisGk) GetString(
 	keyPath []string,
@@ -127,7 +384,145 @@ func (v *RedisGk) GetString(
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(keyPath)
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return "", fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.Get(ctx, keyP).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+		}
+		return "", fmt.Errorf("error getting key %s: %w", keyP, err)
+	}
+
+	return v.decryptString(result)
+}
+
+// decryptString decrypts a string value written by SetString with an Encryptor
+func (v *RedisGk) decryptString(value string) (string, error) {
+	plain, err := v.decryptValue([]byte(value))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// GetBytes gets binary data saved by SetBytes, decrypting and decompressing it if needed
+func (v *RedisGk) GetBytes(
+	keyPath []string,
+) ([]byte, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.Get(ctx, keyP).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+		}
+		return nil, fmt.Errorf("error getting key %s: %w", keyP, err)
+	}
+
+	plain, err := v.openValue(result)
+	if err != nil {
+		return nil, err
+	}
+	return unescapeRawValue(plain), nil
+}
+
+// GetStrings gets strings for an explicit list of keys with one MGET
+// Returns found values by normalized key and the list of missing keys
+func (v *RedisGk) GetStrings(keyPaths [][]string) (map[string]string, []string, error) {
+	if v == nil {
+		return nil, nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if len(keyPaths) == 0 {
+		return nil, nil, fmt.Errorf("no keys specified")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keys, err := v.convertKeyPaths(keyPaths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := v.mget(ctx, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := make(map[string]string)
+	var missing []string
+
+	for i, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			missing = append(missing, keys[i])
+			continue
+		}
+		plain, err := v.decryptString(str)
+		if err != nil {
+			return nil, nil, fmt.Errorf("key %s: %w", keys[i], err)
+		}
+		results[keys[i]] = plain
+	}
+
+	return results, missing, nil
+}
+
+// GetDelString atomically gets string and deletes the key with GETDEL (Redis 6.2+)
+// Returns ErrKeyNotFound if the key is absent
+func (v *RedisGk) GetDelString(
+	keyPath []string,
+) (string, error) {
+	if v == nil {
+		return "", fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return "", fmt.Errorf("key conversion error: %w", err)
+	}
+
+	result, err := v.redisClient.GetDel(ctx, keyP).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
+		}
+		return "", fmt.Errorf("error getting and deleting key %s: %w", keyP, err)
+	}
+
+	return v.decryptString(result)
+}
+
+// GetRawString gets value from Redis as stored, e.g. the JSON written by SetObj
+func (v *RedisGk) GetRawString(
+	keyPath []string,
+) (string, error) {
+	if v == nil {
+		return "", fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
 	if err != nil {
 		return "", fmt.Errorf("key conversion error: %w", err)
 	}
@@ -135,7 +530,7 @@ func (v *RedisGk) GetString(
 	result, err := v.redisClient.Get(ctx, keyP).Result()
 	if err != nil {
 		if err == redis.Nil {
-			return "", fmt.Errorf("key not found: %s", keyP)
+			return "", fmt.Errorf("%w: %s", ErrKeyNotFound, keyP)
 		}
 		return "", fmt.Errorf("error getting key %s: %w", keyP, err)
 	}
@@ -158,7 +553,7 @@ func (v *RedisGk) Del(keyPath ...[]string) error {
 
 	keysPDel := make([]string, 0, len(keyPath))
 	for i, key := range keyPath {
-		keyM, err := slicePathsConvertor(key)
+		keyM, err := v.slicePathsConvertor(key)
 		if err != nil {
 			return fmt.Errorf("key conversion error %d: %w", i, err)
 		}
@@ -178,14 +573,60 @@ func (v *RedisGk) Del(keyPath ...[]string) error {
 	return nil
 }
 
+// DelByPattern deletes keys by pattern with non-blocking UNLINK and returns how many were removed
+// Keys are unlinked batch by batch while scanning. Keys deleted by someone else after
+// SCAN returned them are not counted, keys created during the scan may be missed.
+func (v *RedisGk) DelByPattern(patternPath []string) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	pattern, err := v.prefixPatternConvertor(patternPath)
+	if err != nil {
+		return 0, fmt.Errorf("pattern conversion error: %w", err)
+	}
+
+	var total int64
+	var cursor uint64
+
+	for {
+		var keys []string
+		keys, cursor, err = v.scanKeysPage(ctx, pattern, cursor, 500, "")
+		if err != nil {
+			return total, err
+		}
+
+		if len(keys) > 0 {
+			removed, err := v.redisClient.Unlink(ctx, keys...).Result()
+			if err != nil {
+				return total, fmt.Errorf("error deleting keys: %w", err)
+			}
+			total += removed
+		}
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return total, nil
+}
+
 // FindKeyByPattern finds key by pattern and returns its value
+// The pattern is normalized like a key written with the same slice, elements may hold *
+// Returns empty key and value if nothing matches, or ErrNoMatch with NoMatchError
 func (v *RedisGk) FindKeyByPattern(patterns []string) (string, string, error) {
 	if v == nil || v.redisClient == nil {
 		return "", "", fmt.Errorf("listener key event manager or client is nil")
 	}
 
-	pattern := strings.Join(patterns, ":")
-	pattern = pathRedisController(pattern)
+	pattern, err := v.slicePathsConvertor(patterns)
+	if err != nil {
+		return "", "", fmt.Errorf("pattern conversion error: %w", err)
+	}
 
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
@@ -209,10 +650,14 @@ func (v *RedisGk) FindKeyByPattern(patterns []string) (string, string, error) {
 		return "", "", fmt.Errorf("scan error: %w", err)
 	}
 
-	return "", "", fmt.Errorf("no keys found for pattern %s", pattern)
+	if v.noMatchError {
+		return "", "", fmt.Errorf("%w: %s", ErrNoMatch, pattern)
+	}
+	return "", "", nil
 }
 
 // FindObj searches objects by key pattern
+// Returns an empty map if nothing is found, or ErrNoMatch with NoMatchError
 func FindObj[T any](
 	v *RedisGk,
 	patternPath []string,
@@ -225,76 +670,138 @@ func FindObj[T any](
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	pattern, err := slicePathsConvertor(patternPath)
+	pattern, err := v.prefixPatternConvertor(patternPath)
 	if err != nil {
 		return nil, fmt.Errorf("pattern conversion error: %w", err)
 	}
-	pattern += "*"
 
 	results := make(map[string]*T)
-	var cursor uint64
 
-	var count int64 = 100 // Default value
-	if len(countRes) > 0 {
-		count = countRes[0]
-		if count <= 0 {
-			count = 100
+	err = v.scanStringValues(ctx, pattern, scanCount(countRes), func(key, jsonStr string) {
+		var obj T
+		if err := v.unmarshalValue([]byte(jsonStr), &obj); err != nil {
+			// Skip objects with deserialization errors
+			return
 		}
+
+		// Add result directly to map
+		results[key] = &obj
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 && v.noMatchError {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatch, pattern)
 	}
 
-	// Process results directly without additional goroutines
-	for {
-		var keys []string
-		keys, cursor, err = v.redisClient.Scan(ctx, cursor, pattern, count).Result()
-		if err != nil {
-			return nil, fmt.Errorf("key scanning error: %w", err)
-		}
+	return results, nil
+}
 
-		if len(keys) == 0 {
-			if cursor == 0 {
-				break
-			}
-			continue
+// FindObjReport - result of FindObjDetailed
+type FindObjReport[T any] struct {
+	Objects map[string]*T    // Successfully decoded objects
+	Null    []string         // Keys holding JSON null
+	Failed  map[string]error // Keys whose value couldn't be decoded into T
+	Missing []string         // Keys deleted during the search or holding a non-string type
+}
+
+// FindObjDetailed searches objects by key pattern like FindObj, but instead of skipping
+// keys it can't return reports them, which helps to detect schema drift
+// With NoMatchError returns ErrNoMatch if no key matches
+func FindObjDetailed[T any](
+	v *RedisGk,
+	patternPath []string,
+	countRes ...int64,
+) (*FindObjReport[T], error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	pattern, err := v.prefixPatternConvertor(patternPath)
+	if err != nil {
+		return nil, fmt.Errorf("pattern conversion error: %w", err)
+	}
+
+	report := &FindObjReport[T]{
+		Objects: make(map[string]*T),
+		Null:    []string{},
+		Failed:  make(map[string]error),
+		Missing: []string{},
+	}
+
+	err = v.scanValues(ctx, pattern, scanCount(countRes), func(key, jsonStr string, found bool) {
+		if !found {
+			report.Missing = append(report.Missing, key)
+			return
 		}
 
-		// Get values for all keys in one request
-		values, err := v.redisClient.MGet(ctx, keys...).Result()
+		// Null is checked on the decoded payload: an encrypted, compressed or
+		// type-tagged null is stored differently
+		var obj T
+		plain, err := v.openValue([]byte(jsonStr))
+		if err == nil {
+			plain, err = untagValue(plain, &obj)
+		}
 		if err != nil {
-			return nil, fmt.Errorf("error getting values: %w", err)
+			report.Failed[key] = fmt.Errorf("object deserialization error: %w", err)
+			return
+		}
+		if string(bytes.TrimSpace(plain)) == "null" {
+			report.Null = append(report.Null, key)
+			return
+		}
+		if err := json.Unmarshal(plain, &obj); err != nil {
+			report.Failed[key] = fmt.Errorf("object deserialization error: %w", err)
+			return
 		}
+		report.Objects[key] = &obj
+	})
+	if err != nil {
+		return nil, err
+	}
+	if v.noMatchError && len(report.Objects)+len(report.Null)+len(report.Failed)+len(report.Missing) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatch, pattern)
+	}
 
-		// Process results
-		for i, value := range values {
-			if value == nil {
-				continue
-			}
+	return report, nil
+}
 
-			jsonStr, ok := value.(string)
-			if !ok {
-				continue
-			}
+// FindRaw searches values by key pattern and returns them as stored, without deserialization
+// Returns an empty map if nothing is found, or ErrNoMatch with NoMatchError
+func (v *RedisGk) FindRaw(patternPath []string, count int64) (map[string]string, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
 
-			var obj T
-			err = json.Unmarshal([]byte(jsonStr), &obj)
-			if err != nil {
-				// Skip objects with deserialization errors
-				continue
-			}
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
 
-			// Add result directly to map
-			results[keys[i]] = &obj
-		}
+	pattern, err := v.prefixPatternConvertor(patternPath)
+	if err != nil {
+		return nil, fmt.Errorf("pattern conversion error: %w", err)
+	}
 
-		if cursor == 0 {
-			break
-		}
+	results := make(map[string]string)
+
+	err = v.scanStringValues(ctx, pattern, scanCount([]int64{count}), func(key, value string) {
+		results[key] = value
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 && v.noMatchError {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatch, pattern)
 	}
 
 	return results, nil
 }
 
 // GetKeys returns list of keys by pattern
-func (v *RedisGk) GetKeys(patternPath []string) ([]string, error) {
+// An optional type filter (KeyTypeString, KeyTypeHash, ...) is passed to SCAN TYPE (Redis 6.0+)
+func (v *RedisGk) GetKeys(patternPath []string, typeFilter ...string) ([]string, error) {
 	if v == nil {
 		return nil, fmt.Errorf("RedisGk instance is nil")
 	}
@@ -302,20 +809,24 @@ func (v *RedisGk) GetKeys(patternPath []string) ([]string, error) {
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	pattern, err := slicePathsConvertor(patternPath)
+	pattern, err := v.prefixPatternConvertor(patternPath)
 	if err != nil {
 		return nil, fmt.Errorf("pattern conversion error: %w", err)
 	}
-	pattern += "*"
+
+	keyType := ""
+	if len(typeFilter) > 0 {
+		keyType = typeFilter[0]
+	}
 
 	var allKeys []string
 	var cursor uint64
 
 	for {
 		var keys []string
-		keys, cursor, err = v.redisClient.Scan(ctx, cursor, pattern, 100).Result()
+		keys, cursor, err = v.scanKeysPage(ctx, pattern, cursor, 100, keyType)
 		if err != nil {
-			return nil, fmt.Errorf("key scanning error: %w", err)
+			return nil, err
 		}
 
 		allKeys = append(allKeys, keys...)
@@ -328,6 +839,44 @@ func (v *RedisGk) GetKeys(patternPath []string) ([]string, error) {
 	return allKeys, nil
 }
 
+// GetKeysPage returns one page of keys by pattern starting from cursor
+// Start with cursor 0 and pass the returned cursor to the next call until it is 0.
+// SCAN may return a key more than once and pages may be shorter or longer than count.
+func (v *RedisGk) GetKeysPage(patternPath []string, cursor uint64, count int64) ([]string, uint64, error) {
+	if v == nil {
+		return nil, 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	pattern, err := v.prefixPatternConvertor(patternPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("pattern conversion error: %w", err)
+	}
+
+	return v.scanKeysPage(ctx, pattern, cursor, scanCount([]int64{count}), "")
+}
+
+// CountKeys counts keys by pattern with SCAN without keeping them in memory
+// SCAN may return a key twice while Redis rehashes, so the count is approximate
+// for a keyspace that changes during the scan
+func (v *RedisGk) CountKeys(patternPath []string) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	pattern, err := v.prefixPatternConvertor(patternPath)
+	if err != nil {
+		return 0, fmt.Errorf("pattern conversion error: %w", err)
+	}
+
+	return v.countByPattern(ctx, pattern)
+}
+
 // Exists checks key existence
 func (v *RedisGk) Exists(key []string) (bool, error) {
 	if v == nil {
@@ -337,7 +886,7 @@ func (v *RedisGk) Exists(key []string) (bool, error) {
 	ctx, cancel := v.createContextWithTimeout()
 	defer cancel()
 
-	keyP, err := slicePathsConvertor(key)
+	keyP, err := v.slicePathsConvertor(key)
 	if err != nil {
 		return false, fmt.Errorf("key conversion error: %w", err)
 	}
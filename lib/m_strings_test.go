@@ -0,0 +1,694 @@
+package redisgklib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestGetKeysTypeFilter(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+
+	if err := v.SetString(testKey(prefix, "str"), "v", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.RPush(testKey(prefix, "list"), "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SAdd(testKey(prefix, "set"), "a"); err != nil {
+		t.Fatal(err)
+	}
+	type pair struct {
+		A string `redis:"a"`
+	}
+	for _, name := range []string{"hash1", "hash2"} {
+		if err := HSetStruct(v, testKey(prefix, name), pair{A: "1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hashes, err := v.GetKeys(prefix, KeyTypeHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(hashes)
+	want := []string{testKeyName(t, v, prefix, "hash1"), testKeyName(t, v, prefix, "hash2")}
+	if !slices.Equal(hashes, want) {
+		t.Errorf("hashes: got %v, want %v", hashes, want)
+	}
+
+	all, err := v.GetKeys(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 5 {
+		t.Errorf("without a filter got %d keys, want 5", len(all))
+	}
+}
+
+func TestDefaultTTL(t *testing.T) {
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{DefaultTTL: time.Hour})
+
+	// Records the expiration arguments of SET commands
+	sets := map[string]string{}
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if args := cmd.Args(); cmd.Name() == "set" {
+			var opts []string
+			for _, arg := range args[3:] {
+				opts = append(opts, argString(arg))
+			}
+			sets[argString(args[1])] = strings.Join(opts, " ")
+		}
+		return false, nil
+	}
+
+	if err := SetObj(v, []string{"obj", "default"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString([]string{"str", "default"}, "v"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString([]string{"str", "zero"}, "v", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetObj(v, []string{"obj", "explicit"}, 1, 90*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString([]string{"str", "explicit"}, "v", 1500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"obj:default":  "ex 3600",
+		"str:default":  "ex 3600",
+		"str:zero":     "ex 3600",
+		"obj:explicit": "ex 90",
+		"str:explicit": "px 1500",
+	}
+	for key, opts := range want {
+		if sets[key] != opts {
+			t.Errorf("SET %s expiration %q, want %q", key, sets[key], opts)
+		}
+	}
+
+	noDefault, fake := newFakeRedisGk(t)
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if cmd.Name() == "set" && len(cmd.Args()) > 3 {
+			t.Errorf("SET without TTL or DefaultTTL sent expiration %v", cmd.Args()[3:])
+		}
+		return false, nil
+	}
+	if err := noDefault.SetString([]string{"str"}, "v"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetObjMany(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	for _, id := range []string{"1", "3", "5"} {
+		if err := SetObj(v, []string{"users", id}, user{Name: "u" + id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fake.data["users:6"] = "not json"
+
+	found, missing, err := GetObjMany[user](v, [][]string{
+		{"users", "1"}, {"users", "2"}, {"Users", "3"}, {"users", "4"}, {"users", "5"}, {"users", "6"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(found) != 3 {
+		t.Errorf("found %d objects, want 3", len(found))
+	}
+	for _, id := range []string{"1", "3", "5"} {
+		if u := found["users:"+id]; u == nil || u.Name != "u"+id {
+			t.Errorf("users:%s: got %+v, want name u%s", id, u, id)
+		}
+	}
+	if !slices.Equal(missing, []string{"users:2", "users:4"}) {
+		t.Errorf("missing: got %v, want [users:2 users:4]", missing)
+	}
+	if _, ok := found["users:6"]; ok {
+		t.Error("undecodable value returned as found")
+	}
+}
+
+func TestFindRawAndGetRawString(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	type doc struct {
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+	}
+	if err := SetObj(v, []string{"docs", "1"}, doc{Title: "a & b", Tags: []string{"x"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetObj(v, []string{"docs", "2"}, doc{Title: "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString([]string{"other"}, "v"); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := v.FindRaw([]string{"docs"}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != 2 {
+		t.Fatalf("found %d values, want 2: %v", len(raw), raw)
+	}
+	for key, value := range raw {
+		stored, _ := fake.get(key)
+		if value != stored {
+			t.Errorf("%s: got %s, want the stored %s", key, value, stored)
+		}
+	}
+	if raw["docs:2"] != `{"title":"c","tags":null}` {
+		t.Errorf("docs:2: got %s", raw["docs:2"])
+	}
+
+	value, err := v.GetRawString([]string{"docs", "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := fake.get("docs:1"); value != stored {
+		t.Errorf("GetRawString: got %s, want %s", value, stored)
+	}
+}
+
+func TestGetKeysPage(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+
+	seeded := make(map[string]bool, 300)
+	for i := range 300 {
+		name := strconv.Itoa(i)
+		if err := v.SetString(testKey(prefix, name), "v", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		seeded[testKeyName(t, v, prefix, name)] = true
+	}
+
+	seen := make(map[string]bool, len(seeded))
+	var cursor uint64
+	pages := 0
+	for {
+		keys, next, err := v.GetKeysPage(prefix, cursor, 50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages++
+		for _, key := range keys {
+			if !seeded[key] {
+				t.Fatalf("page returned unexpected key %s", key)
+			}
+			if seen[key] {
+				t.Fatalf("key %s returned twice", key)
+			}
+			seen[key] = true
+		}
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+
+	if len(seen) != len(seeded) {
+		t.Errorf("pages covered %d of %d keys", len(seen), len(seeded))
+	}
+	if pages < 2 {
+		t.Errorf("300 keys returned in %d page, want several", pages)
+	}
+}
+
+func TestGetObjWithMigration(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	// Version 1 stored the age as a string, version 2 as a number
+	type userV2 struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	fake.data["users:1"] = `{"name":"alice","age":"42"}`
+	if err := SetObj(v, []string{"users", "2"}, userV2{Name: "bob", Age: 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	migrations := 0
+	migrate := func(data []byte) ([]byte, error) {
+		migrations++
+		var old struct {
+			Name string `json:"name"`
+			Age  string `json:"age"`
+		}
+		if err := json.Unmarshal(data, &old); err != nil {
+			return nil, err
+		}
+		age, err := strconv.Atoi(old.Age)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(userV2{Name: old.Name, Age: age})
+	}
+
+	got, err := GetObjWithMigration[userV2](v, []string{"users", "1"}, migrate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != (userV2{Name: "alice", Age: 42}) {
+		t.Errorf("migrated object: got %+v", *got)
+	}
+
+	got, err = GetObjWithMigration[userV2](v, []string{"users", "2"}, migrate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != (userV2{Name: "bob", Age: 7}) {
+		t.Errorf("current object: got %+v", *got)
+	}
+	if migrations != 1 {
+		t.Errorf("migrate called %d times, want only for the old object", migrations)
+	}
+
+	fake.data["users:3"] = `{"name":"carol","age":"unknown"}`
+	if _, err := GetObjWithMigration[userV2](v, []string{"users", "3"}, migrate); err == nil {
+		t.Error("failed migration returned no error")
+	}
+}
+
+func TestCountKeys(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+
+	for i := range 250 {
+		if err := v.SetString(testKey(prefix, "count", strconv.Itoa(i)), "v", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := v.SetString(testKey(prefix, "other"), "v", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := v.CountKeys(testKey(prefix, "count", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 250 {
+		t.Errorf("CountKeys: got %d, want 250", count)
+	}
+}
+
+func TestDelByPatternConcurrentWrites(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	stable := testKey(prefix, "items", "stable")
+	churn := testKey(prefix, "items", "churn")
+
+	for i := range 500 {
+		if err := v.SetString(testKey(stable, strconv.Itoa(i)), "v", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Another writer adds and removes matching keys during the scan
+	stop := make(chan struct{})
+	done := make(chan int)
+	go func() {
+		created := 0
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				done <- created
+				return
+			default:
+			}
+			key := testKey(churn, strconv.Itoa(i))
+			if v.SetString(key, "v", time.Minute) == nil {
+				created++
+			}
+			if i%2 == 0 {
+				v.Del(key)
+			}
+		}
+	}()
+
+	removed, err := v.DelByPattern(testKey(prefix, "items"))
+	close(stop)
+	created := <-done
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed < 500 || removed > int64(500+created) {
+		t.Errorf("DelByPattern removed %d, want between 500 and %d", removed, 500+created)
+	}
+
+	left, err := v.CountKeys(stable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if left != 0 {
+		t.Errorf("%d keys existing before the scan were not deleted", left)
+	}
+}
+
+func TestGetDelConcurrent(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "token")
+
+	if err := SetObj(v, key, listItem{ID: 7}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan error, 2)
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item, err := GetDelObj[listItem](v, key)
+			if err == nil && item.ID != 7 {
+				err = fmt.Errorf("unexpected object %+v", item)
+			}
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	successes := 0
+	for err := range results {
+		switch {
+		case err == nil:
+			successes++
+		case !errors.Is(err, ErrKeyNotFound):
+			t.Errorf("GetDelObj: %v", err)
+		}
+	}
+	if successes != 1 {
+		t.Errorf("%d GetDelObj calls got the token, want exactly one", successes)
+	}
+
+	if err := v.SetString(key, "once", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := v.GetDelString(key); err != nil || value != "once" {
+		t.Errorf("GetDelString: got %q, %v", value, err)
+	}
+	if _, err := v.GetDelString(key); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("second GetDelString: got %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestGetStrings(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	for _, id := range []string{"1", "3"} {
+		if err := v.SetString([]string{"names", id}, "name"+id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mgets := 0
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if cmd.Name() == "mget" {
+			mgets++
+		}
+		return false, nil
+	}
+
+	found, missing, err := v.GetStrings([][]string{{"names", "1"}, {"names", "2"}, {"Names", "3"}, {"names", "4"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"names:1": "name1", "names:3": "name3"}; !maps.Equal(found, want) {
+		t.Errorf("found: got %v, want %v", found, want)
+	}
+	if !slices.Equal(missing, []string{"names:2", "names:4"}) {
+		t.Errorf("missing: got %v, want [names:2 names:4]", missing)
+	}
+	if mgets != 1 {
+		t.Errorf("%d MGET calls, want one", mgets)
+	}
+}
+
+func TestFindObjKeySeparator(t *testing.T) {
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{KeySeparator: "/"})
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	if err := SetObj(v, []string{"Users", "1"}, user{Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fake.get("users/1"); !ok {
+		t.Fatalf("object not stored under users/1, keys: %v", fake.data)
+	}
+	// Same path joined with the default separator
+	fake.data["users:1"] = `{"name":"Bob"}`
+
+	found, err := FindObj[user](v, []string{"users", "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found["users/1"] == nil || found["users/1"].Name != "Alice" {
+		t.Errorf("FindObj: got %v, want only users/1", found)
+	}
+}
+
+func TestGetObjOrNil(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	type user struct {
+		Name string `json:"name"`
+	}
+
+	got, err := GetObjOrNil[user](v, []string{"users", "missing"})
+	if got != nil || err != nil {
+		t.Errorf("missing key: got %v, %v, want nil, nil", got, err)
+	}
+
+	fake.data["users:broken"] = "{not json"
+	if got, err := GetObjOrNil[user](v, []string{"users", "broken"}); got != nil || err == nil {
+		t.Errorf("undecodable value: got %v, %v, want an error", got, err)
+	}
+
+	if err := SetObj(v, []string{"users", "1"}, user{Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := GetObjOrNil[user](v, []string{"users", "1"}); err != nil || got == nil || got.Name != "Alice" {
+		t.Errorf("existing key: got %v, %v", got, err)
+	}
+}
+
+func TestLongKeyHashedOnSetAndGet(t *testing.T) {
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{MaxKeyLength: 80})
+	long := []string{"reports", strings.Repeat("segment", 30)}
+
+	if err := v.SetString(long, "report"); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := v.GetString(long); err != nil || value != "report" {
+		t.Fatalf("GetString of the long key: got %q, %v", value, err)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	for key := range fake.data {
+		if len(key) > 80 || !strings.HasPrefix(key, "reports:") {
+			t.Errorf("stored key %q is not a hashed key with a readable prefix", key)
+		}
+	}
+}
+
+func TestFindObjDetailedBuckets(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	type doc struct {
+		Title string `json:"title"`
+	}
+	if err := SetObj(v, []string{"docs", "valid"}, doc{Title: "ok"}); err != nil {
+		t.Fatal(err)
+	}
+	fake.data["docs:malformed"] = `{"title": 42}`
+	fake.data["docs:null"] = "null"
+	fake.data["docs:gone"] = `{"title":"deleted"}`
+
+	// The key is deleted between SCAN and MGET
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if cmd.Name() == "mget" {
+			delete(fake.data, "docs:gone")
+		}
+		return false, nil
+	}
+
+	report, err := FindObjDetailed[doc](v, []string{"docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Objects) != 1 || report.Objects["docs:valid"] == nil || report.Objects["docs:valid"].Title != "ok" {
+		t.Errorf("objects: got %v, want docs:valid", report.Objects)
+	}
+	if _, ok := report.Failed["docs:malformed"]; !ok || len(report.Failed) != 1 {
+		t.Errorf("failed: got %v, want docs:malformed", report.Failed)
+	}
+	if !slices.Equal(report.Null, []string{"docs:null"}) {
+		t.Errorf("null: got %v, want [docs:null]", report.Null)
+	}
+	if !slices.Equal(report.Missing, []string{"docs:gone"}) {
+		t.Errorf("missing: got %v, want [docs:gone]", report.Missing)
+	}
+}
+
+func TestFindObjMGetLengthMismatch(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+	fake.data["items:1"] = `{"name":"a"}`
+	fake.data["items:2"] = `{"name":"b"}`
+
+	// A misbehaving proxy answers MGET with one value for two keys
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if cmd.Name() != "mget" {
+			return false, nil
+		}
+		cmd.(*redis.SliceCmd).SetVal([]any{`{"name":"a"}`})
+		return true, nil
+	}
+
+	type item struct {
+		Name string `json:"name"`
+	}
+	found, err := FindObj[item](v, []string{"items"})
+	if err == nil || !strings.Contains(err.Error(), "MGET returned 1 values for 2 keys") {
+		t.Errorf("FindObj: got %v, %v, want the MGET length error", found, err)
+	}
+}
+
+func TestGetObjWithTTL(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+
+	type session struct {
+		User string `json:"user"`
+	}
+	expiring := testKey(prefix, "expiring")
+	if err := SetObj(v, expiring, session{User: "alice"}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	got, ttl, err := GetObjWithTTL[session](v, expiring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.User != "alice" {
+		t.Errorf("object: got %+v, want alice", got)
+	}
+	if ttl <= 50*time.Second || ttl > time.Minute {
+		t.Errorf("TTL %s, want close to 1m", ttl)
+	}
+
+	persistent := testKey(prefix, "persistent")
+	if err := SetObj(v, persistent, session{User: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, ttl, err := GetObjWithTTL[session](v, persistent); err != nil || got.User != "bob" || ttl != 0 {
+		t.Errorf("key without expiration: got %+v, %s, %v, want bob with TTL 0", got, ttl, err)
+	}
+
+	if _, _, err := GetObjWithTTL[session](v, testKey(prefix, "missing")); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("missing key: got %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestNoMatchBehavior(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+	}
+	pattern := []string{"missing"}
+
+	v, fake := newFakeRedisGk(t)
+	fake.data["items:1"] = `{"name":"a"}`
+
+	// Default: empty results and no error from every search
+	if key, value, err := v.FindKeyByPattern([]string{"missing", "*"}); err != nil || key != "" || value != "" {
+		t.Errorf("FindKeyByPattern: got %q, %q, %v, want empty", key, value, err)
+	}
+	if found, err := FindObj[item](v, pattern); err != nil || len(found) != 0 {
+		t.Errorf("FindObj: got %v, %v, want empty", found, err)
+	}
+	if report, err := FindObjDetailed[item](v, pattern); err != nil || len(report.Objects) != 0 {
+		t.Errorf("FindObjDetailed: got %+v, %v, want empty", report, err)
+	}
+	if found, err := v.FindRaw(pattern, 0); err != nil || len(found) != 0 {
+		t.Errorf("FindRaw: got %v, %v, want empty", found, err)
+	}
+
+	// NoMatchError: ErrNoMatch from every search
+	strict, fake := newFakeRedisGk(t, RedisAdditionalOptions{NoMatchError: true})
+	fake.data["items:1"] = `{"name":"a"}`
+
+	if _, _, err := strict.FindKeyByPattern([]string{"missing", "*"}); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("FindKeyByPattern: got %v, want ErrNoMatch", err)
+	}
+	if _, err := FindObj[item](strict, pattern); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("FindObj: got %v, want ErrNoMatch", err)
+	}
+	if _, err := FindObjDetailed[item](strict, pattern); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("FindObjDetailed: got %v, want ErrNoMatch", err)
+	}
+	if _, err := strict.FindRaw(pattern, 0); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("FindRaw: got %v, want ErrNoMatch", err)
+	}
+
+	// A match is not affected
+	if found, err := FindObj[item](strict, []string{"items"}); err != nil || len(found) != 1 {
+		t.Errorf("FindObj with a match: got %v, %v", found, err)
+	}
+}
+
+func TestFindObjDetailedEncodedNull(t *testing.T) {
+	encryptor, err := NewAESGCMEncryptor(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{Encryptor: encryptor, TypeTags: true})
+
+	type doc struct {
+		Title string `json:"title"`
+	}
+	if err := SetObj(v, []string{"docs", "valid"}, doc{Title: "ok"}); err != nil {
+		t.Fatal(err)
+	}
+	// An encrypted, type-tagged null
+	null, err := v.encodeValue((*doc)(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.data["docs:null"] = string(null)
+	// A type-tagged null written without encryption
+	fake.data["docs:tagged"] = typeTagPrefix + `"redisgklib.doc","value":null}`
+
+	report, err := FindObjDetailed[doc](v, []string{"docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Objects) != 1 || report.Objects["docs:valid"] == nil {
+		t.Errorf("objects: got %v, want only docs:valid", report.Objects)
+	}
+	slices.Sort(report.Null)
+	if !slices.Equal(report.Null, []string{"docs:null", "docs:tagged"}) {
+		t.Errorf("null: got %v, want [docs:null docs:tagged]", report.Null)
+	}
+	if len(report.Failed) != 0 {
+		t.Errorf("failed: got %v", report.Failed)
+	}
+}
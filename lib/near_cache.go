@@ -0,0 +1,206 @@
+package redisgklib
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// NearCache - in-process cache of T objects stored under a key prefix by SetObj
+// Misses are read from Redis, entries are evicted on key events (set, del, expired, ...)
+// of their keys and the least recently used entry is evicted when the cache is full.
+// Invalidation is asynchronous, so a read right after a change by another client may
+// still return the old object; events dropped by a full event queue or discarded by
+// PauseEvents leave stale entries until they are evicted by size or Invalidate.
+// Call Close when the cache is no longer needed to stop its invalidation goroutine.
+type NearCache[T any] struct {
+	v          *RedisGk
+	prefix     []string
+	maxEntries int
+
+	// Invalidation goroutine, nil watcher - not started
+	watcher   *eventWatcher
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	// Incremented by every eviction, a miss stores its result only if it didn't change
+	generation uint64
+}
+
+// nearCacheEntry - cached object with its Redis key
+type nearCacheEntry[T any] struct {
+	key   string
+	value T
+}
+
+// NewNearCache creates a near cache of T objects under the prefix holding up to maxEntries
+// objects (<= 0 - 1000). Without the key event listener (SkipServerSetup) nothing is
+// cached and every Get reads Redis. The cache must be released with Close.
+func NewNearCache[T any](v *RedisGk, prefix []string, maxEntries int) *NearCache[T] {
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+
+	c := &NearCache[T]{
+		v:          v,
+		prefix:     append([]string(nil), prefix...),
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	if v == nil || v.listenerKeyEventManager == nil {
+		c.maxEntries = 0
+		return c
+	}
+
+	pattern, err := v.prefixPatternConvertor(prefix)
+	if err != nil {
+		c.maxEntries = 0
+		return c
+	}
+
+	em := v.listenerKeyEventManager
+	watcher := em.addWatcher(pattern, 100)
+
+	started := em.spawn(func() {
+		defer func() {
+			em.removeWatcher(watcher)
+			close(c.done)
+		}()
+
+		for {
+			select {
+			case <-c.stop:
+				return
+			case <-em.ctx.Done():
+				return
+			case event, ok := <-watcher.ch:
+				if !ok {
+					return
+				}
+				c.evict(event.Key)
+			}
+		}
+	})
+	if !started {
+		em.removeWatcher(watcher)
+		c.maxEntries = 0
+		return c
+	}
+
+	c.watcher = watcher
+	return c
+}
+
+// Close stops invalidation and empties the cache, later Gets read Redis
+// It waits for the invalidation goroutine to exit and may be called more than once
+func (c *NearCache[T]) Close() {
+	c.closeOnce.Do(func() {
+		if c.watcher != nil {
+			close(c.stop)
+			<-c.done
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.maxEntries = 0
+		c.generation++
+		c.entries = make(map[string]*list.Element)
+		c.lru.Init()
+	})
+}
+
+// Get returns the object with the id from memory, or reads it from Redis on a miss
+// The result is a copy, changing it doesn't change the cached object
+func (c *NearCache[T]) Get(id string) (*T, error) {
+	if c.v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+	if id == "" {
+		return nil, fmt.Errorf("id is empty")
+	}
+
+	keyPath := append(append([]string(nil), c.prefix...), id)
+	key, err := c.v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		value := elem.Value.(*nearCacheEntry[T]).value
+		c.mu.Unlock()
+		return &value, nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	result, err := GetObj[T](c.v, keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(key, *result, generation)
+	return result, nil
+}
+
+// store caches the value read from Redis unless an eviction happened during the read
+func (c *NearCache[T]) store(key string, value T, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maxEntries <= 0 || c.generation != generation {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*nearCacheEntry[T]).value = value
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&nearCacheEntry[T]{key: key, value: value})
+	if c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*nearCacheEntry[T]).key)
+	}
+}
+
+// evict removes the entry of the Redis key
+func (c *NearCache[T]) evict(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// Invalidate removes the object with the id from memory, the next Get reads Redis
+func (c *NearCache[T]) Invalidate(id string) {
+	if c.v == nil || id == "" {
+		return
+	}
+	key, err := c.v.slicePathsConvertor(append(append([]string(nil), c.prefix...), id))
+	if err != nil {
+		return
+	}
+	c.evict(key)
+}
+
+// Len returns the number of objects held in memory
+func (c *NearCache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
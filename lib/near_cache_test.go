@@ -0,0 +1,89 @@
+package redisgklib
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNearCacheEvictsOnDelete(t *testing.T) {
+	v, server := newFakeEventRedisGk(t, RedisAdditionalOptions{})
+	defer v.Close()
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	server.mu.Lock()
+	server.data["users:1"] = `{"name":"Alice"}`
+	server.mu.Unlock()
+
+	cache := NewNearCache[user](v, []string{"users"}, 10)
+	defer cache.Close()
+
+	if got, err := cache.Get("1"); err != nil || got.Name != "Alice" {
+		t.Fatalf("first Get: got %+v, %v", got, err)
+	}
+
+	// A change without a key event is not seen, the entry is served from memory
+	server.mu.Lock()
+	server.data["users:1"] = `{"name":"Bob"}`
+	server.mu.Unlock()
+	if got, err := cache.Get("1"); err != nil || got.Name != "Alice" {
+		t.Fatalf("cached Get: got %+v, %v, want Alice", got, err)
+	}
+
+	// Deleted on the Redis side by another client
+	server.waitForSubscriber(t, "__keyevent@0__:del")
+	server.mu.Lock()
+	delete(server.data, "users:1")
+	server.mu.Unlock()
+	server.publish("__keyevent@0__:del", "users:1")
+
+	waitFor(t, "the entry to be evicted", func() bool {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		return len(cache.entries) == 0
+	})
+	if _, err := cache.Get("1"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get after the delete: got %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestNearCacheEvictsOnSet(t *testing.T) {
+	v, server := newFakeEventRedisGk(t, RedisAdditionalOptions{})
+	defer v.Close()
+
+	server.mu.Lock()
+	notify := server.notify
+	server.data["users:1"] = `{"name":"Alice"}`
+	server.mu.Unlock()
+	if !strings.Contains(notify, "$") {
+		t.Errorf("notify-keyspace-events set to %q, SET doesn't publish events without $", notify)
+	}
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	cache := NewNearCache[user](v, []string{"users"}, 10)
+	defer cache.Close()
+
+	if got, err := cache.Get("1"); err != nil || got.Name != "Alice" {
+		t.Fatalf("first Get: got %+v, %v", got, err)
+	}
+
+	// Updated on the Redis side by another client, without a TTL
+	server.waitForSubscriber(t, "__keyevent@0__:set")
+	server.mu.Lock()
+	server.data["users:1"] = `{"name":"Bob"}`
+	server.mu.Unlock()
+	server.publish("__keyevent@0__:set", "users:1")
+
+	waitFor(t, "the entry to be evicted", func() bool {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		return len(cache.entries) == 0
+	})
+	if got, err := cache.Get("1"); err != nil || got.Name != "Bob" {
+		t.Errorf("Get after the update: got %+v, %v, want Bob", got, err)
+	}
+}
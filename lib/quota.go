@@ -0,0 +1,137 @@
+package redisgklib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// quotaRefreshInterval - how long a counted number of keys under a prefix is reused
+const quotaRefreshInterval = 5 * time.Second
+
+// prefixQuota - key limit for one prefix with the cached key count
+type prefixQuota struct {
+	mu          sync.Mutex
+	prefix      string
+	maxKeys     int64
+	count       int64
+	refreshedAt time.Time
+}
+
+// quotaManager - client-side key limits per prefix
+type quotaManager struct {
+	mu     sync.RWMutex
+	quotas map[string]*prefixQuota
+}
+
+// newQuotaManager creates a new quota manager instance
+func newQuotaManager() *quotaManager {
+	return &quotaManager{quotas: make(map[string]*prefixQuota)}
+}
+
+// SetPrefixQuota limits the number of keys SetObj and SetString may create under the prefix
+// Keys under the prefix are the prefix key itself and keys continuing it after KeySeparator,
+// so a quota on ["tenant1"] doesn't count tenant10:... keys. maxKeys <= 0 removes the limit.
+// Writes that would create a new key over the limit fail with ErrQuotaExceeded,
+// overwriting an existing key is always allowed.
+// The check is best-effort: the key count is cached for a few seconds and keys written
+// by other clients or through GetRedisClient are only seen on refresh.
+func (v *RedisGk) SetPrefixQuota(patternPath []string, maxKeys int64) error {
+	if v == nil || v.quotas == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	prefix, err := v.normalizeKeyPath(patternPath)
+	if err != nil {
+		return fmt.Errorf("pattern conversion error: %w", err)
+	}
+
+	v.quotas.mu.Lock()
+	defer v.quotas.mu.Unlock()
+
+	if maxKeys <= 0 {
+		delete(v.quotas.quotas, prefix)
+		return nil
+	}
+
+	v.quotas.quotas[prefix] = &prefixQuota{prefix: prefix, maxKeys: maxKeys}
+	return nil
+}
+
+// checkQuota checks that writing the key doesn't exceed quotas of its prefixes
+func (v *RedisGk) checkQuota(ctx context.Context, key string) error {
+	if v.quotas == nil {
+		return nil
+	}
+
+	v.quotas.mu.RLock()
+	var matched []*prefixQuota
+	for prefix, quota := range v.quotas.quotas {
+		if keyUnderPrefix(key, prefix, v.separator()) {
+			matched = append(matched, quota)
+		}
+	}
+	v.quotas.mu.RUnlock()
+
+	for _, quota := range matched {
+		if err := v.checkPrefixQuota(ctx, quota, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keyUnderPrefix reports whether the key is the prefix or continues it after the separator
+func keyUnderPrefix(key, prefix, sep string) bool {
+	return key == prefix || strings.HasPrefix(key, prefix+sep)
+}
+
+// countPrefixKeys counts the prefix key and the keys under it
+func (v *RedisGk) countPrefixKeys(ctx context.Context, prefix string) (int64, error) {
+	count, err := v.countByPattern(ctx, prefix+v.separator()+"*")
+	if err != nil {
+		return 0, err
+	}
+
+	exists, err := v.redisClient.Exists(ctx, prefix).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	return count + exists, nil
+}
+
+// checkPrefixQuota checks one prefix quota, refreshing the cached count if it is stale
+func (v *RedisGk) checkPrefixQuota(ctx context.Context, quota *prefixQuota, key string) error {
+	quota.mu.Lock()
+	defer quota.mu.Unlock()
+
+	if time.Since(quota.refreshedAt) > quotaRefreshInterval {
+		count, err := v.countPrefixKeys(ctx, quota.prefix)
+		if err != nil {
+			return fmt.Errorf("error counting keys for quota %s: %w", quota.prefix, err)
+		}
+		quota.count = count
+		quota.refreshedAt = time.Now()
+	}
+
+	// Overwrites don't create keys, so they neither count nor fail
+	exists, err := v.redisClient.Exists(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("error checking key existence: %w", err)
+	}
+	if exists > 0 {
+		return nil
+	}
+
+	if quota.count < quota.maxKeys {
+		// Counted as a new key until the next refresh
+		quota.count++
+		return nil
+	}
+
+	return fmt.Errorf("%w: prefix %s allows %d keys", ErrQuotaExceeded, quota.prefix, quota.maxKeys)
+}
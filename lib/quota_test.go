@@ -0,0 +1,85 @@
+package redisgklib
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestKeyUnderPrefix(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"tenant1", true},
+		{"tenant1:users:1", true},
+		{"tenant10:users:1", false},
+		{"tenant1x", false},
+		{"tenant", false},
+	}
+
+	for _, tt := range tests {
+		if got := keyUnderPrefix(tt.key, "tenant1", ":"); got != tt.want {
+			t.Errorf("keyUnderPrefix(%q, tenant1) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestPrefixQuota(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	tenant := testKey(prefix, "tenant1")
+
+	if err := v.SetPrefixQuota(tenant, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		if err := v.SetString(testKey(tenant, name), "v", time.Minute); err != nil {
+			t.Fatalf("write %s within the quota: %v", name, err)
+		}
+	}
+	if err := v.SetString(testKey(tenant, "c"), "v", time.Minute); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("write over the quota: got %v, want ErrQuotaExceeded", err)
+	}
+	if err := v.SetString(testKey(tenant, "a"), "v2", time.Minute); err != nil {
+		t.Fatalf("overwrite at the quota: %v", err)
+	}
+	if err := v.SetString(testKey(prefix, "tenant10", "x"), "v", time.Minute); err != nil {
+		t.Fatalf("write under a sibling prefix: %v", err)
+	}
+
+	if err := v.SetPrefixQuota(tenant, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString(testKey(tenant, "c"), "v", time.Minute); err != nil {
+		t.Fatalf("write after removing the quota: %v", err)
+	}
+}
+
+func TestPrefixQuotaOverwritesNotCounted(t *testing.T) {
+	v, _ := newFakeRedisGk(t)
+	tenant := []string{"tenant"}
+
+	if err := v.SetPrefixQuota(tenant, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	// One key written three times
+	for i := range 3 {
+		if err := v.SetString([]string{"tenant", "a"}, fmt.Sprint(i)); err != nil {
+			t.Fatalf("write %d of tenant:a: %v", i, err)
+		}
+	}
+	for _, name := range []string{"b", "c"} {
+		if err := v.SetString([]string{"tenant", name}, "v"); err != nil {
+			t.Fatalf("new key %s within the quota: %v", name, err)
+		}
+	}
+	if err := v.SetString([]string{"tenant", "d"}, "v"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("fourth key: got %v, want ErrQuotaExceeded", err)
+	}
+	if err := v.SetString([]string{"tenant", "b"}, "v2"); err != nil {
+		t.Errorf("overwrite at the quota: %v", err)
+	}
+}
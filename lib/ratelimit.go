@@ -0,0 +1,106 @@
+package redisgklib
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fixedWindowScript increments the window counter and sets its expiration on the first hit
+// PTTL check also repairs a counter left without expiration
+var fixedWindowScript = redis.NewScript(`
+local current = redis.call('INCR', KEYS[1])
+if current == 1 or redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return current
+`)
+
+// slidingWindowScript keeps timestamps of allowed hits in a sorted set and
+// removes the ones older than the window before counting
+// Server TIME is used so that clients with skewed clocks share one timeline
+var slidingWindowScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[3])
+	redis.call('PEXPIRE', KEYS[1], math.ceil(window / 1000))
+	return {1, limit - count - 1}
+end
+return {0, 0}
+`)
+
+// RateLimitAllow counts a hit in a fixed window and reports whether it is within the limit
+// Returns whether the hit is allowed and how many hits remain in the current window
+func (v *RedisGk) RateLimitAllow(keyPath []string, limit int64, window time.Duration) (bool, int64, error) {
+	if v == nil {
+		return false, 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if limit <= 0 {
+		return false, 0, fmt.Errorf("limit must be > 0, got: %d", limit)
+	}
+	if window < time.Millisecond {
+		return false, 0, fmt.Errorf("window must be >= 1ms, got: %s", window)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return false, 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	current, err := fixedWindowScript.Run(ctx, v.redisClient, []string{keyP}, window.Milliseconds()).Int64()
+	if err != nil {
+		return false, 0, fmt.Errorf("error checking rate limit: %w", err)
+	}
+
+	return current <= limit, max(limit-current, 0), nil
+}
+
+// SlidingRateLimitAllow counts a hit in a sliding window and reports whether it is within the limit
+// Unlike RateLimitAllow, bursts at a window boundary can't exceed the limit.
+// Each allowed hit is kept as a sorted set entry until it leaves the window,
+// so a key uses memory proportional to limit (roughly 60-100 bytes per entry).
+func (v *RedisGk) SlidingRateLimitAllow(keyPath []string, limit int64, window time.Duration) (bool, int64, error) {
+	if v == nil {
+		return false, 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if limit <= 0 {
+		return false, 0, fmt.Errorf("limit must be > 0, got: %d", limit)
+	}
+	if window < time.Millisecond {
+		return false, 0, fmt.Errorf("window must be >= 1ms, got: %s", window)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return false, 0, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	// Unique member, hits in the same microsecond must not collapse
+	member := strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatUint(rand.Uint64(), 36)
+
+	result, err := slidingWindowScript.Run(ctx, v.redisClient, []string{keyP}, window.Microseconds(), limit, member).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("error checking rate limit: %w", err)
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+
+	return result[0] == 1, result[1], nil
+}
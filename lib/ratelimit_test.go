@@ -0,0 +1,83 @@
+package redisgklib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimitAllow(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "api")
+	const limit, window = 3, 300 * time.Millisecond
+
+	for i := range limit {
+		allowed, remaining, err := v.RateLimitAllow(key, limit, window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !allowed || remaining != int64(limit-i-1) {
+			t.Fatalf("hit %d: got allowed=%v remaining=%d, want true, %d", i+1, allowed, remaining, limit-i-1)
+		}
+	}
+
+	allowed, remaining, err := v.RateLimitAllow(key, limit, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allowed || remaining != 0 {
+		t.Fatalf("hit over the limit: got allowed=%v remaining=%d, want false, 0", allowed, remaining)
+	}
+
+	time.Sleep(window + 50*time.Millisecond)
+	allowed, remaining, err = v.RateLimitAllow(key, limit, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !allowed || remaining != limit-1 {
+		t.Fatalf("hit after the window: got allowed=%v remaining=%d, want true, %d", allowed, remaining, limit-1)
+	}
+}
+
+func TestSlidingRateLimitAtWindowBoundary(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	const limit, window = 3, 400 * time.Millisecond
+
+	type limiter func([]string, int64, time.Duration) (bool, int64, error)
+	burst := func(allow limiter, key []string, n int) int {
+		t.Helper()
+		allowed := 0
+		for range n {
+			ok, _, err := allow(key, limit, window)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok {
+				allowed++
+			}
+		}
+		return allowed
+	}
+
+	fixed, sliding := testKey(prefix, "fixed"), testKey(prefix, "sliding")
+
+	// One hit opens the window, two more just before it ends use up the limit
+	start := time.Now()
+	burst(v.RateLimitAllow, fixed, 1)
+	burst(v.SlidingRateLimitAllow, sliding, 1)
+	time.Sleep(350*time.Millisecond - time.Since(start))
+	if n := burst(v.RateLimitAllow, fixed, 2); n != 2 {
+		t.Fatalf("fixed window allowed %d of 2 hits within the limit", n)
+	}
+	if n := burst(v.SlidingRateLimitAllow, sliding, 2); n != 2 {
+		t.Fatalf("sliding window allowed %d of 2 hits within the limit", n)
+	}
+
+	// Just after the boundary a fixed window starts over, a sliding one only frees the first hit
+	time.Sleep(450*time.Millisecond - time.Since(start))
+	if n := burst(v.RateLimitAllow, fixed, 3); n != 3 {
+		t.Errorf("fixed window allowed %d of 3 hits after the boundary, want 3", n)
+	}
+	if n := burst(v.SlidingRateLimitAllow, sliding, 3); n != 1 {
+		t.Errorf("sliding window allowed %d of 3 hits after the boundary, want 1", n)
+	}
+}
@@ -2,6 +2,7 @@ package redisgklib
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,70 +12,313 @@ import (
 // RedisGk - main structure for working with Redis
 type RedisGk struct {
 	redisClient *redis.Client
+	credentials *credentialsStore
 	baseCtx     time.Duration
+	defaultTTL  time.Duration
+	shadowKeys  bool
+	// Copy values into shadow keys for expired events
+	archiveValues bool
+
+	// Parent of operation contexts, nil means context.Background()
+	parentCtx context.Context
+
+	strictKeyValidation bool
+	keySeparator        string
+	maxKeyLength        int
+	// Disallow direct client access through GetRedisClient
+	strictMode bool
+
+	// Client-side key limits per prefix, shared with views
+	quotas *quotaManager
+	// Cached check of Bloom filter support, shared with views
+	bloomProbe *commandProbe
+	// Clients of other databases opened by WithDB, shared with views
+	dbClients *dbClients
+	// Key routing over nodes of NewRedisGkSharded (nil - single node)
+	shards *shardRouter
+
+	// Value size limit and SetString behavior when it is exceeded
+	maxValueSize int
+	onOversize   OversizePolicy
+	// Values are stored without the MaxValueSize check (SkipSizeCheck view)
+	skipSizeCheck bool
+	// Serialized objects over this size are compressed (0 - disabled)
+	compressionThreshold int
+	// Compress values of any size (WithCompression(true) view)
+	forceCompression bool
+	// Store the Go type name with objects
+	typeTags bool
+	// Encrypts stored values (nil - disabled)
+	encryptor Encryptor
+	// Pattern searches fail with ErrNoMatch instead of returning empty results
+	noMatchError bool
+
+	// JSON serialization options
+	disableHTMLEscape bool
+	jsonIndent        string
+
 	// Key event notification manager
 	listenerKeyEventManager *listenerKeyEventManager
+	// Background connection health check (nil - disabled)
+	healthChecker *healthChecker
+	// Fails commands fast while Redis is unavailable (nil - disabled)
+	circuitBreaker *circuitBreaker
+	logger         Logger
+
+	// View created by With... methods, shares resources with the parent instance
+	isView bool
 }
 
 // NewRedisGk creates a new RedisGk instance
 func NewRedisGk(conf RedisConfConn) (*RedisGk, error) {
 	// Check for empty configuration
-	if (RedisConfConn{}) == conf {
+	if isEmptyConfig(conf) {
 		return nil, fmt.Errorf("configuration is empty")
 	}
 
+	if conf.AdditionalOptions.DefaultTTL < 0 {
+		return nil, fmt.Errorf("DefaultTTL must be >= 0, got: %s", conf.AdditionalOptions.DefaultTTL)
+	}
+
+	if conf.AdditionalOptions.MaxValueSize < 0 {
+		return nil, fmt.Errorf("MaxValueSize must be >= 0, got: %d", conf.AdditionalOptions.MaxValueSize)
+	}
+	if conf.AdditionalOptions.MaxValueSize == 0 || conf.AdditionalOptions.MaxValueSize > maxSizeData {
+		conf.AdditionalOptions.MaxValueSize = maxSizeData
+	}
+
+	if conf.AdditionalOptions.CompressionThreshold < 0 {
+		return nil, fmt.Errorf("CompressionThreshold must be >= 0, got: %d", conf.AdditionalOptions.CompressionThreshold)
+	}
+
+	switch conf.AdditionalOptions.OnOversize {
+	case "":
+		conf.AdditionalOptions.OnOversize = OversizeError
+	case OversizeError, OversizeTruncate:
+	default:
+		return nil, fmt.Errorf("unknown OnOversize policy: %s", conf.AdditionalOptions.OnOversize)
+	}
+
+	if err := validateKeySeparator(conf.AdditionalOptions.KeySeparator); err != nil {
+		return nil, err
+	}
+
+	if conf.AdditionalOptions.MaxKeyLength != 0 && conf.AdditionalOptions.MaxKeyLength < minHashedKeyLength {
+		return nil, fmt.Errorf("MaxKeyLength must be 0 or >= %d, got: %d", minHashedKeyLength, conf.AdditionalOptions.MaxKeyLength)
+	}
+
+	if conf.AdditionalOptions.CircuitBreakerThreshold < 0 {
+		return nil, fmt.Errorf("CircuitBreakerThreshold must be >= 0, got: %d", conf.AdditionalOptions.CircuitBreakerThreshold)
+	}
+
+	if conf.AdditionalOptions.EventQueueHighWater < 0 {
+		return nil, fmt.Errorf("EventQueueHighWater must be >= 0, got: %d", conf.AdditionalOptions.EventQueueHighWater)
+	}
+
+	for _, db := range conf.AdditionalOptions.EventDBs {
+		if db < 0 {
+			return nil, fmt.Errorf("EventDBs must be >= 0, got: %d", db)
+		}
+	}
+
+	if conf.AdditionalOptions.SlowOpThreshold < 0 {
+		return nil, fmt.Errorf("SlowOpThreshold must be >= 0, got: %s", conf.AdditionalOptions.SlowOpThreshold)
+	}
+
 	if conf.AdditionalOptions.BaseCtx == 0 {
 		conf.AdditionalOptions.BaseCtx = 10 * time.Second
 	}
 
-	redisClient, err := newRedisClientConnector(conf)
+	redisClient, credentials, err := newRedisClientConnector(conf)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create context for initialization
-	ctx := context.Background()
+	var hooks []redis.Hook
 
-	// Initialize Redis client with configuration check and subscription to notifications
-	initializer := newRedisInitializer(redisClient, ctx)
-	if initializer == nil {
-		return nil, fmt.Errorf("failed to create redis initializer")
+	// Fail fast on repeated connection failures if enabled
+	breaker := newCircuitBreaker(conf.AdditionalOptions)
+	if breaker != nil {
+		hooks = append(hooks, breaker)
 	}
-	if err := initializer.initializeWithKeyExpirationNotifications(); err != nil {
-		return nil, err
+
+	// Report slow commands if enabled
+	if hook := newSlowOpHook(
+		conf.AdditionalOptions.SlowOpThreshold,
+		conf.AdditionalOptions.Logger,
+		conf.AdditionalOptions.MetricsCollector,
+	); hook != nil {
+		hooks = append(hooks, hook)
 	}
 
-	// Create key event notification manager
-	listenerKeyEventManager := newListenerKeyEventManager(redisClient, context.Background())
-	if listenerKeyEventManager == nil {
-		return nil, fmt.Errorf("failed to create listener key event manager")
+	// Wrap known error replies into sentinel errors, innermost so other hooks see them
+	hooks = append(hooks, errorHook{})
+
+	for _, hook := range hooks {
+		redisClient.AddHook(hook)
+	}
+
+	// Without server setup there is no key event subscription
+	var listenerKeyEventManager *listenerKeyEventManager
+	if !conf.AdditionalOptions.SkipServerSetup {
+		// Create context for initialization
+		ctx := context.Background()
+
+		// Initialize Redis client with configuration check and subscription to notifications
+		initializer := newRedisInitializer(redisClient, ctx)
+		if initializer == nil {
+			return nil, fmt.Errorf("failed to create redis initializer")
+		}
+		if err := initializer.initializeWithKeyExpirationNotifications(); err != nil {
+			return nil, err
+		}
+
+		// Create key event notification manager
+		listenerKeyEventManager = newListenerKeyEventManager(redisClient, context.Background(), conf.AdditionalOptions)
+		if listenerKeyEventManager == nil {
+			return nil, fmt.Errorf("failed to create listener key event manager")
+		}
 	}
 
 	redisGk := &RedisGk{
 		redisClient:             redisClient,
+		credentials:             credentials,
 		baseCtx:                 conf.AdditionalOptions.BaseCtx,
+		defaultTTL:              conf.AdditionalOptions.DefaultTTL,
+		shadowKeys:              conf.AdditionalOptions.ShadowKeys || conf.AdditionalOptions.ArchiveExpiredValues,
+		archiveValues:           conf.AdditionalOptions.ArchiveExpiredValues,
+		strictKeyValidation:     conf.AdditionalOptions.StrictKeyValidation,
+		keySeparator:            conf.AdditionalOptions.KeySeparator,
+		maxKeyLength:            conf.AdditionalOptions.MaxKeyLength,
+		strictMode:              conf.AdditionalOptions.StrictMode,
+		maxValueSize:            conf.AdditionalOptions.MaxValueSize,
+		onOversize:              conf.AdditionalOptions.OnOversize,
+		compressionThreshold:    conf.AdditionalOptions.CompressionThreshold,
+		typeTags:                conf.AdditionalOptions.TypeTags,
+		noMatchError:            conf.AdditionalOptions.NoMatchError,
+		encryptor:               conf.AdditionalOptions.Encryptor,
+		disableHTMLEscape:       conf.AdditionalOptions.DisableHTMLEscape,
+		jsonIndent:              conf.AdditionalOptions.JSONIndent,
+		quotas:                  newQuotaManager(),
+		bloomProbe:              newCommandProbe("BF.ADD"),
+		dbClients:               newDBClients(redisClient, hooks),
+		circuitBreaker:          breaker,
 		listenerKeyEventManager: listenerKeyEventManager,
+		logger:                  conf.AdditionalOptions.Logger,
 	}
 
 	// Automatically start key event notification listener
-	if err := redisGk.listenerKeyEventManager.start(); err != nil {
-		return nil, err
+	if listenerKeyEventManager != nil {
+		// Values of events from other databases are read through their clients
+		listenerKeyEventManager.dbClients = redisGk.dbClients
+		if err := listenerKeyEventManager.start(); err != nil {
+			return nil, err
+		}
 	}
 
+	// Start background health check if enabled
+	redisGk.healthChecker = newHealthChecker(
+		redisClient,
+		conf.AdditionalOptions.HealthCheckInterval,
+		conf.AdditionalOptions.Logger,
+		listenerKeyEventManager.reconnect,
+	)
+	redisGk.healthChecker.start()
+
 	return redisGk, nil
 }
 
 // Close closes Redis connection
+// Calling Close on a view returned by With... methods does nothing
 func (v *RedisGk) Close() error {
+	return v.close(0)
+}
+
+// CloseWithTimeout closes Redis connection like Close, but waits at most d for
+// background goroutines to complete. The connection is closed in any case;
+// ErrCloseTimeout is returned if goroutines didn't exit in time.
+func (v *RedisGk) CloseWithTimeout(d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("timeout must be > 0, got: %s", d)
+	}
+	return v.close(d)
+}
+
+// close stops background goroutines and closes the connection (timeout 0 - no limit)
+func (v *RedisGk) close(timeout time.Duration) error {
+	if v.isView {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	remaining := func() time.Duration {
+		if timeout <= 0 {
+			return 0
+		}
+		// Keep a positive value, 0 would mean no limit
+		return max(time.Until(deadline), time.Nanosecond)
+	}
+
+	clean := true
+
+	// Stop health check before the connection is closed
+	if !v.healthChecker.stop(remaining()) {
+		clean = false
+	}
+
 	// Stop notification manager
 	if v.listenerKeyEventManager != nil {
-		v.listenerKeyEventManager.stop()
+		if !v.listenerKeyEventManager.stopWithTimeout(remaining()) {
+			clean = false
+		}
 	}
 
+	var err error
 	if v.redisClient != nil {
-		return v.redisClient.Close()
+		err = v.redisClient.Close()
 	}
-	return nil
+	err = errors.Join(err, v.dbClients.close(), v.shards.close())
+
+	if !clean {
+		return errors.Join(fmt.Errorf("%w after %s", ErrCloseTimeout, timeout), err)
+	}
+	return err
+}
+
+// PauseEvents stops delivering key events until ResumeEvents is called
+// The subscription stays open and events received while paused are discarded
+func (v *RedisGk) PauseEvents() {
+	if v == nil || v.listenerKeyEventManager == nil {
+		return
+	}
+	v.listenerKeyEventManager.paused.Store(true)
+}
+
+// ResumeEvents resumes delivering key events after PauseEvents
+func (v *RedisGk) ResumeEvents() {
+	if v == nil || v.listenerKeyEventManager == nil {
+		return
+	}
+	v.listenerKeyEventManager.paused.Store(false)
+}
+
+// DroppedEvents returns the number of key events discarded because the event queue
+// or the buffer of a watcher (SnapshotAndWatch, ForwardEvents, ...) was full
+func (v *RedisGk) DroppedEvents() uint64 {
+	if v == nil || v.listenerKeyEventManager == nil {
+		return 0
+	}
+	return v.listenerKeyEventManager.droppedEvents.Load()
+}
+
+// EventQueueDepth returns the number of key events waiting in the event queue
+// A depth close to EventQueueSize means consumers are falling behind and events
+// will soon be dropped or, with OverflowBlock, the subscription will stall
+func (v *RedisGk) EventQueueDepth() int {
+	if v == nil || v.listenerKeyEventManager == nil {
+		return 0
+	}
+	return len(v.listenerKeyEventManager.queue)
 }
 
 // ListenChannelKeyEventManager returns channel for receiving key event notifications
@@ -90,6 +334,160 @@ func (v *RedisGk) ListenChannelKeyEventManager() <-chan KeyEvent {
 }
 
 // GetRedisClient returns the Redis client
+// Returns nil in StrictMode, so all access goes through the wrapped API
 func (v *RedisGk) GetRedisClient() *redis.Client {
+	if v == nil || v.strictMode {
+		return nil
+	}
 	return v.redisClient
 }
+
+// UpdateCredentials replaces user and password used for new connections
+// New credentials are checked on a separate connection before they are applied, so this
+// works both while the old password is still accepted and after it was revoked.
+// Connections that are already authenticated stay open, Redis doesn't drop them on password change.
+func (v *RedisGk) UpdateCredentials(user, password string) error {
+	if v == nil || v.redisClient == nil {
+		return fmt.Errorf("RedisGk instance or client is nil")
+	}
+	if v.credentials == nil {
+		return fmt.Errorf("credentials are managed by CredentialsProvider")
+	}
+	if password == "" {
+		return fmt.Errorf("password is required")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	// A pooled connection would authenticate with the old credentials first
+	opts := *v.redisClient.Options()
+	opts.CredentialsProvider = func() (string, string) { return user, password }
+	opts.PoolSize = 1
+	opts.MinIdleConns = 0
+	opts.MaxRetries = -1
+	check := redis.NewClient(&opts)
+	defer check.Close()
+
+	if err := check.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("error checking new credentials: %w", err)
+	}
+
+	v.credentials.set(user, password)
+	return nil
+}
+
+// AsUser runs fn with a view of the instance whose commands go through a dedicated
+// connection authenticated as the given ACL user
+// The connection is opened and checked with AUTH before fn runs and closed after it returns,
+// so every call costs a new connection outside of the pool, and commands of fn run one at
+// a time on it. The view passed to fn must not be used after fn returns. Key events and
+// other background work keep using the main connection pool.
+func (v *RedisGk) AsUser(user, password string, fn func(*RedisGk) error) error {
+	if v == nil || v.redisClient == nil {
+		return fmt.Errorf("RedisGk instance or client is nil")
+	}
+	if fn == nil {
+		return fmt.Errorf("fn is nil")
+	}
+	if v.shards != nil {
+		return fmt.Errorf("AsUser is not supported by a sharded instance")
+	}
+
+	opts := *v.redisClient.Options()
+	opts.Username = user
+	opts.Password = password
+	opts.CredentialsProvider = nil
+	opts.CredentialsProviderContext = nil
+	opts.StreamingCredentialsProvider = nil
+	opts.PoolSize = 1
+	opts.MinIdleConns = 0
+	opts.MaxIdleConns = 1
+
+	client := redis.NewClient(&opts)
+	defer client.Close()
+
+	if v.circuitBreaker != nil {
+		client.AddHook(v.circuitBreaker)
+	}
+	client.AddHook(errorHook{})
+
+	ctx, cancel := v.createContextWithTimeout()
+	err := client.Ping(ctx).Err()
+	cancel()
+	if err != nil {
+		return fmt.Errorf("error authenticating as user %s: %w", user, err)
+	}
+
+	view := v.view()
+	view.redisClient = client
+	view.credentials = nil
+
+	return fn(view)
+}
+
+// WithTimeout returns a view of the instance whose operations use the given timeout
+// instead of BaseCtx. The view shares the connection and event listener with v.
+func (v *RedisGk) WithTimeout(d time.Duration) *RedisGk {
+	if v == nil {
+		return nil
+	}
+
+	view := v.view()
+	if d > 0 {
+		view.baseCtx = d
+	}
+	return view
+}
+
+// WithContext returns a view of the instance whose operations derive their contexts from ctx,
+// so values like request IDs and trace spans reach Redis hooks and the Logger.
+// Cancelling ctx cancels the operations in progress. The view shares the connection
+// and event listener with v.
+func (v *RedisGk) WithContext(ctx context.Context) *RedisGk {
+	if v == nil {
+		return nil
+	}
+
+	view := v.view()
+	view.parentCtx = ctx
+	return view
+}
+
+// SkipSizeCheck returns a view of the instance that stores values without checking them
+// against MaxValueSize, for hot paths whose callers already control value sizes.
+// OnOversize is not applied either; Redis still rejects values over 512 MB.
+// The view shares the connection and event listener with v.
+func (v *RedisGk) SkipSizeCheck() *RedisGk {
+	if v == nil {
+		return nil
+	}
+
+	view := v.view()
+	view.skipSizeCheck = true
+	return view
+}
+
+// WithCompression returns a view of the instance that overrides CompressionThreshold:
+// enabled - compression is attempted for values of any size, disabled - values are stored
+// uncompressed, e.g. data that is already compressed. Reads detect compressed values in
+// any case. The view shares the connection and event listener with v.
+func (v *RedisGk) WithCompression(enabled bool) *RedisGk {
+	if v == nil {
+		return nil
+	}
+
+	view := v.view()
+	view.forceCompression = enabled
+	if !enabled {
+		view.compressionThreshold = 0
+	}
+	return view
+}
+
+// view returns a shallow copy of the instance sharing all resources
+func (v *RedisGk) view() *RedisGk {
+	clone := *v
+	clone.isView = true
+	return &clone
+}
@@ -0,0 +1,388 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedisGk connects to the Redis server set by REDISGK_TEST_HOST, REDISGK_TEST_PORT
+// (default 6379) and REDISGK_TEST_PASSWORD, skipping the test if no host is set
+// Returns the instance and the unique key prefix of the test; keys under it are
+// deleted and the instance is closed when the test ends.
+func newTestRedisGk(t testing.TB, opts ...RedisAdditionalOptions) (*RedisGk, []string) {
+	t.Helper()
+
+	host := os.Getenv("REDISGK_TEST_HOST")
+	if host == "" {
+		t.Skip("REDISGK_TEST_HOST is not set, skipping test against Redis")
+	}
+	port := 6379
+	if p := os.Getenv("REDISGK_TEST_PORT"); p != "" {
+		var err error
+		if port, err = strconv.Atoi(p); err != nil {
+			t.Fatalf("invalid REDISGK_TEST_PORT %q: %v", p, err)
+		}
+	}
+
+	conf := RedisConfConn{
+		Host:     host,
+		Port:     port,
+		Password: os.Getenv("REDISGK_TEST_PASSWORD"),
+	}
+	if len(opts) > 0 {
+		conf.AdditionalOptions = opts[0]
+	}
+
+	v, err := NewRedisGk(conf)
+	if err != nil {
+		t.Fatalf("error connecting to Redis: %v", err)
+	}
+
+	prefix := []string{"redisgk_test", strings.ToLower(t.Name()), strconv.FormatInt(time.Now().UnixNano(), 36)}
+	t.Cleanup(func() {
+		if _, err := v.DelByPattern(prefix); err != nil {
+			t.Errorf("error deleting test keys: %v", err)
+		}
+		if err := v.Close(); err != nil {
+			t.Errorf("error closing: %v", err)
+		}
+	})
+
+	return v, prefix
+}
+
+// testKey returns the key path of name under the test prefix
+func testKey(prefix []string, name ...string) []string {
+	return append(append([]string(nil), prefix...), name...)
+}
+
+// testKeyName returns the normalized Redis key of name under the test prefix
+func testKeyName(t testing.TB, v *RedisGk, prefix []string, name ...string) string {
+	t.Helper()
+
+	key, err := v.slicePathsConvertor(testKey(prefix, name...))
+	if err != nil {
+		t.Fatal(fmt.Errorf("key conversion error: %w", err))
+	}
+	return key
+}
+
+// waitForEvent returns the first event of the channel accepted by match, failing the
+// test if none arrives within timeout or the channel is closed
+func waitForEvent(t *testing.T, events <-chan KeyEvent, timeout time.Duration, match func(KeyEvent) bool) KeyEvent {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("event channel closed before the expected event")
+			}
+			if match(event) {
+				return event
+			}
+		case <-timer.C:
+			t.Fatalf("no expected event within %s", timeout)
+		}
+	}
+}
+
+// waitFor polls cond until it holds, failing the test after 5 seconds
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+	fake.data["slow"] = "value"
+
+	// Replies take 100ms unless the command context ends first
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+			return false, nil
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	}
+
+	_, err := v.WithTimeout(10 * time.Millisecond).GetString([]string{"slow"})
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrTimeout) {
+		t.Fatalf("call with a 10ms timeout: got %v, want ErrTimeout wrapping context.DeadlineExceeded", err)
+	}
+
+	value, err := v.GetString([]string{"slow"})
+	if err != nil {
+		t.Fatalf("call with the default timeout: %v", err)
+	}
+	if value != "value" {
+		t.Errorf("got %q, want %q", value, "value")
+	}
+}
+
+func TestWithTimeoutLongScan(t *testing.T) {
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{BaseCtx: 50 * time.Millisecond})
+	fake.data["docs:1"] = `{"title":"a"}`
+
+	// SCAN over a huge keyspace takes 100ms, point reads are fast
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		if cmd.Name() != "scan" {
+			return false, nil
+		}
+		select {
+		case <-time.After(100 * time.Millisecond):
+			return false, nil
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	}
+
+	type doc struct {
+		Title string `json:"title"`
+	}
+	if _, err := FindObj[doc](v, []string{"docs"}); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("scan with the 50ms BaseCtx: got %v, want ErrTimeout", err)
+	}
+
+	found, err := FindObj[doc](v.WithTimeout(time.Second), []string{"docs"})
+	if err != nil || len(found) != 1 {
+		t.Fatalf("scan with an extended timeout: got %v, %v", found, err)
+	}
+
+	// The view doesn't change the timeout of the instance
+	if _, err := v.GetString([]string{"docs", "1"}); err != nil {
+		t.Errorf("point read with BaseCtx: %v", err)
+	}
+	if _, err := FindObj[doc](v, []string{"docs"}); !errors.Is(err, ErrTimeout) {
+		t.Errorf("scan after the extended call: got %v, want ErrTimeout", err)
+	}
+}
+
+func TestUpdateCredentials(t *testing.T) {
+	server := newFakeServer(t, "old")
+
+	v, err := NewRedisGk(server.conf("old"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	if err := v.SetString([]string{"k"}, "v"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wrong credentials are rejected and not applied
+	if err := v.UpdateCredentials("", "wrong"); err == nil {
+		t.Fatal("UpdateCredentials accepted a wrong password")
+	}
+
+	// The old password is already revoked and pooled connections are gone
+	// when the rotation reaches the instance
+	server.setPasswords("new")
+	server.dropConnections()
+	if err := v.UpdateCredentials("", "new"); err != nil {
+		t.Fatalf("UpdateCredentials: %v", err)
+	}
+
+	server.dropConnections()
+	before := len(server.authAttempts())
+	value, err := v.GetString([]string{"k"})
+	if err != nil || value != "v" {
+		t.Fatalf("GetString after rotation: got %q, %v", value, err)
+	}
+	auths := server.authAttempts()[before:]
+	if len(auths) == 0 {
+		t.Fatal("no new connection was authenticated")
+	}
+	for _, auth := range auths {
+		if auth != "default new" {
+			t.Errorf("new connection authenticated with %q, want the new password", auth)
+		}
+	}
+}
+
+func TestCredentialsProviderRotatingTokens(t *testing.T) {
+	server := newFakeServer(t, "token-1")
+	server.setPasswords("token-1", "token-2", "token-3")
+
+	var mu sync.Mutex
+	issued := 0
+	conf := server.conf("")
+	conf.CredentialsProvider = func() (string, string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		issued++
+		return "", fmt.Sprintf("token-%d", issued), nil
+	}
+
+	v, err := NewRedisGk(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	for i := range 3 {
+		if err := v.SetString([]string{"k"}, "v"); err != nil {
+			t.Fatalf("SetString on connection %d: %v", i+1, err)
+		}
+		server.dropConnections()
+	}
+
+	// Every new connection authenticated with a fresh token
+	want := []string{"default token-1", "default token-2", "default token-3"}
+	if auths := server.authAttempts(); !slices.Equal(auths, want) {
+		t.Errorf("AUTH attempts %q, want %q", auths, want)
+	}
+
+	if err := v.UpdateCredentials("", "static"); err == nil {
+		t.Error("UpdateCredentials accepted static credentials with a CredentialsProvider")
+	}
+}
+
+// requestIDKey - context key of the request ID in TestWithContextReachesLogger
+type requestIDKey struct{}
+
+// requestIDLogger - Logger collecting request IDs of the contexts it is called with
+type requestIDLogger struct {
+	mu  sync.Mutex
+	ids []any
+}
+
+func (l *requestIDLogger) Printf(ctx context.Context, format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ids = append(l.ids, ctx.Value(requestIDKey{}))
+}
+
+func TestWithContextReachesLogger(t *testing.T) {
+	logger := &requestIDLogger{}
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{
+		SlowOpThreshold: time.Millisecond,
+		Logger:          logger,
+	})
+
+	// Every command is slow, so the slow operation hook logs it
+	fake.handle = func(ctx context.Context, cmd redis.Cmder) (bool, error) {
+		time.Sleep(5 * time.Millisecond)
+		return false, nil
+	}
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-42")
+	if err := v.WithContext(ctx).SetString([]string{"k"}, "v"); err != nil {
+		t.Fatal(err)
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if len(logger.ids) == 0 {
+		t.Fatal("slow command was not logged")
+	}
+	for _, id := range logger.ids {
+		if id != "req-42" {
+			t.Errorf("Logger called with request ID %v, want req-42", id)
+		}
+	}
+}
+
+func TestStrictModeHidesClient(t *testing.T) {
+	v, _ := newFakeRedisGk(t)
+	if v.GetRedisClient() == nil {
+		t.Error("GetRedisClient returned nil without StrictMode")
+	}
+
+	strict, _ := newFakeRedisGk(t, RedisAdditionalOptions{StrictMode: true})
+	if client := strict.GetRedisClient(); client != nil {
+		t.Error("GetRedisClient returned a client in StrictMode")
+	}
+
+	// Views keep the mode of their instance
+	if strict.WithTimeout(time.Second).GetRedisClient() != nil {
+		t.Error("view of a strict instance returned a client")
+	}
+}
+
+func TestSkipServerSetup(t *testing.T) {
+	server := newFakeServer(t, "secret")
+
+	v, err := NewRedisGk(server.conf("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	// A server knowing only SET and GET is enough
+	if err := v.SetString([]string{"k"}, "v"); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := v.GetString([]string{"k"}); err != nil || value != "v" {
+		t.Fatalf("GetString: got %q, %v", value, err)
+	}
+
+	for _, name := range server.receivedCommands() {
+		switch name {
+		case "ping", "config", "subscribe":
+			t.Errorf("%s sent with SkipServerSetup", strings.ToUpper(name))
+		}
+	}
+	if v.ListenChannelKeyEventManager() != nil {
+		t.Error("key event channel available with SkipServerSetup")
+	}
+}
+
+func TestAsUser(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	server.addUser("tenant", "tenant-secret")
+
+	v, err := NewRedisGk(server.conf("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	err = v.AsUser("tenant", "tenant-secret", func(tenant *RedisGk) error {
+		return tenant.SetString([]string{"tenant", "k"}, "v")
+	})
+	if err != nil {
+		t.Fatalf("AsUser: %v", err)
+	}
+	if err := v.SetString([]string{"main", "k"}, "v"); err != nil {
+		t.Fatal(err)
+	}
+
+	if user := server.writer("tenant:k"); user != "tenant" {
+		t.Errorf("operation in AsUser ran as %q, want tenant", user)
+	}
+	if user := server.writer("main:k"); user != "default" {
+		t.Errorf("operation after AsUser ran as %q, want default", user)
+	}
+
+	called := false
+	err = v.AsUser("tenant", "wrong", func(*RedisGk) error {
+		called = true
+		return nil
+	})
+	if err == nil || called {
+		t.Errorf("AsUser with a wrong password: err %v, fn called %v", err, called)
+	}
+}
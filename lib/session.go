@@ -0,0 +1,49 @@
+package redisgklib
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LoadSession gets an object and resets its TTL to slide in one atomic command (GETEX, Redis 6.2+)
+// Each load keeps the session alive for another slide, so it expires only after slide
+// without loads. The bool reports whether the session exists; a missing or expired
+// session returns (nil, false, nil).
+func LoadSession[T any](
+	v *RedisGk,
+	keyPath []string,
+	slide time.Duration,
+) (*T, bool, error) {
+	if v == nil {
+		return nil, false, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	if slide < time.Millisecond {
+		return nil, false, fmt.Errorf("slide must be >= 1ms, got: %s", slide)
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("key conversion error: %w", err)
+	}
+
+	jsonStr, err := v.redisClient.GetEx(ctx, keyP, slide).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("error loading session %s: %w", keyP, err)
+	}
+
+	var result T
+	if err := v.unmarshalValue([]byte(jsonStr), &result); err != nil {
+		return nil, true, fmt.Errorf("object deserialization error: %w", err)
+	}
+
+	return &result, true, nil
+}
@@ -0,0 +1,39 @@
+package redisgklib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadSessionSlidesTTL(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "session")
+
+	type session struct {
+		UserID int `json:"user_id"`
+	}
+	if err := SetObj(v, key, session{UserID: 7}, 300*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	// Loads every 200ms keep the 300ms session alive well past its first TTL
+	for range 4 {
+		time.Sleep(200 * time.Millisecond)
+		got, ok, err := LoadSession[session](v, key, 300*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok || got.UserID != 7 {
+			t.Fatalf("session expired while being loaded: %v, %v", got, ok)
+		}
+	}
+
+	time.Sleep(400 * time.Millisecond)
+	got, ok, err := LoadSession[session](v, key, 300*time.Millisecond)
+	if err != nil || ok || got != nil {
+		t.Errorf("expired session: got %v, %v, %v, want nil, false, nil", got, ok, err)
+	}
+	if _, ok, err := LoadSession[session](v, testKey(prefix, "missing"), time.Second); err != nil || ok {
+		t.Errorf("missing session: got %v, %v, want false", ok, err)
+	}
+}
@@ -0,0 +1,228 @@
+package redisgklib
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// shadowKeyPrefix - namespace for companion keys, kept outside of user key paths
+const shadowKeyPrefix = "__redisgk_shadow:"
+
+// shadowKeyGrace - how long a shadow key outlives its primary key
+const shadowKeyGrace = 5 * time.Minute
+
+// shadowKey returns the companion key name for the primary key
+func shadowKey(key string) string {
+	return shadowKeyPrefix + key
+}
+
+// isShadowKey checks if key belongs to the shadow namespace
+func isShadowKey(key string) bool {
+	return strings.HasPrefix(key, shadowKeyPrefix)
+}
+
+// shadowRecord - contents of a shadow key
+type shadowRecord struct {
+	ttl       time.Duration
+	createdAt time.Time
+	value     string
+	hasValue  bool
+}
+
+// storeValue checks prefix quotas and saves value; if shadow keys are enabled and TTL is set,
+// writes the companion key with creation time, original TTL and, with ArchiveExpiredValues,
+// a copy of the value in one transaction
+func (v *RedisGk) storeValue(ctx context.Context, key string, value any, ttl time.Duration) error {
+	if err := v.checkQuota(ctx, key); err != nil {
+		return err
+	}
+
+	if !v.shadowKeys || ttl <= 0 {
+		return v.redisClient.Set(ctx, key, value, ttl).Err()
+	}
+
+	_, err := v.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		v.queueStore(ctx, pipe, key, value, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error saving key with shadow %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// queueStore queues the SET of the value and, like storeValue, the companion key writes
+// Used by callers running their own transaction, quotas are checked by the caller
+func (v *RedisGk) queueStore(ctx context.Context, pipe redis.Pipeliner, key string, value any, ttl time.Duration) {
+	pipe.Set(ctx, key, value, ttl)
+	if !v.shadowKeys || ttl <= 0 {
+		return
+	}
+
+	shadow := shadowKey(key)
+	fields := []any{
+		"created_at", time.Now().UTC().UnixMilli(),
+		"ttl", ttl.Milliseconds(),
+	}
+	if v.archiveValues {
+		fields = append(fields, "value", value)
+	} else {
+		// Drop a value archived by an earlier write
+		pipe.HDel(ctx, shadow, "value")
+	}
+	pipe.HSet(ctx, shadow, fields...)
+	pipe.PExpire(ctx, shadow, ttl+shadowKeyGrace)
+}
+
+// readShadow reads and removes the companion key of an expired key
+func (em *listenerKeyEventManager) readShadow(db int, key string) (shadowRecord, error) {
+	ctx, cancel := context.WithTimeout(em.ctx, 5*time.Second)
+	defer cancel()
+
+	client, err := em.clientForDB(ctx, db)
+	if err != nil {
+		return shadowRecord{}, err
+	}
+
+	shadow := shadowKey(key)
+	fields, err := client.HGetAll(ctx, shadow).Result()
+	if err != nil {
+		return shadowRecord{}, fmt.Errorf("failed to get shadow key %s: %w", shadow, err)
+	}
+	if len(fields) == 0 {
+		return shadowRecord{}, fmt.Errorf("shadow key %s not found", shadow)
+	}
+
+	createdMs, err := strconv.ParseInt(fields["created_at"], 10, 64)
+	if err != nil {
+		return shadowRecord{}, fmt.Errorf("invalid created_at in shadow key %s: %w", shadow, err)
+	}
+	ttlMs, err := strconv.ParseInt(fields["ttl"], 10, 64)
+	if err != nil {
+		return shadowRecord{}, fmt.Errorf("invalid ttl in shadow key %s: %w", shadow, err)
+	}
+
+	// Shadow key is no longer needed once the primary key has expired
+	client.Del(ctx, shadow)
+
+	value, hasValue := fields["value"]
+
+	return shadowRecord{
+		ttl:       time.Duration(ttlMs) * time.Millisecond,
+		createdAt: time.UnixMilli(createdMs).UTC(),
+		value:     value,
+		hasValue:  hasValue,
+	}, nil
+}
+
+// deleteOrphanScript deletes a companion key if its primary key doesn't exist
+// A shadow key (ARGV[1] = 1) is kept while its TTL is within the grace period: the primary
+// may have just expired and the listener still needs the shadow for the expired event
+var deleteOrphanScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[2]) == 1 then
+	return 0
+end
+if ARGV[1] == '1' and redis.call('PTTL', KEYS[1]) <= tonumber(ARGV[2]) then
+	return 0
+end
+return redis.call('DEL', KEYS[1])
+`)
+
+// CleanupOrphans deletes companion keys (shadow keys, RPushUnique id sets) of keys under
+// the pattern (nil - all keys) whose primary key no longer exists, e.g. after a raw DEL
+// Note that the id set of a list drained by pops is removed too, so those ids may be pushed again.
+// Returns the number of deleted keys.
+func (v *RedisGk) CleanupOrphans(patternPath []string) (int, error) {
+	if v == nil {
+		return 0, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	pattern := "*"
+	if len(patternPath) > 0 {
+		prefixPattern, err := v.prefixPatternConvertor(patternPath)
+		if err != nil {
+			return 0, fmt.Errorf("pattern conversion error: %w", err)
+		}
+		pattern = prefixPattern
+	}
+
+	deleted := 0
+	for _, companionPrefix := range []string{shadowKeyPrefix, uniqueIDsKeyPrefix} {
+		isShadow := "0"
+		if companionPrefix == shadowKeyPrefix {
+			isShadow = "1"
+		}
+
+		var cursor uint64
+		for {
+			keys, next, err := v.scanKeysPage(ctx, companionPrefix+pattern, cursor, 1000, "")
+			if err != nil {
+				return deleted, err
+			}
+
+			n, err := v.deleteOrphanPage(ctx, keys, companionPrefix, isShadow)
+			deleted += n
+			if err != nil {
+				return deleted, err
+			}
+
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+	}
+
+	return deleted, nil
+}
+
+// deleteOrphanPage runs deleteOrphanScript for a SCAN page of companion keys in one pipeline
+// The script is sent by SHA and resent in full only if the server doesn't have it cached
+func (v *RedisGk) deleteOrphanPage(ctx context.Context, keys []string, companionPrefix, isShadow string) (int, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	run := func(useSha bool) ([]*redis.Cmd, error) {
+		cmds := make([]*redis.Cmd, len(keys))
+		_, err := v.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range keys {
+				scriptKeys := []string{key, strings.TrimPrefix(key, companionPrefix)}
+				if useSha {
+					cmds[i] = deleteOrphanScript.EvalSha(ctx, pipe, scriptKeys, isShadow, shadowKeyGrace.Milliseconds())
+				} else {
+					cmds[i] = deleteOrphanScript.Eval(ctx, pipe, scriptKeys, isShadow, shadowKeyGrace.Milliseconds())
+				}
+			}
+			return nil
+		})
+		return cmds, err
+	}
+
+	cmds, err := run(true)
+	if err != nil && redis.HasErrorPrefix(err, "NOSCRIPT") {
+		cmds, err = run(false)
+	}
+
+	deleted := 0
+	for i, cmd := range cmds {
+		n, cmdErr := cmd.Int()
+		if cmdErr != nil {
+			return deleted, fmt.Errorf("error deleting orphan %s: %w", keys[i], cmdErr)
+		}
+		deleted += n
+	}
+	if err != nil {
+		return deleted, fmt.Errorf("error deleting orphans: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,99 @@
+package redisgklib
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestShadowKeysExpiredEventMetadata(t *testing.T) {
+	v, prefix := newTestRedisGk(t, RedisAdditionalOptions{ShadowKeys: true})
+	key := testKey(prefix, "session")
+	keyName := testKeyName(t, v, prefix, "session")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := v.SnapshotAndWatch(ctx, prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := time.Now()
+	if err := v.SetString(key, "value", 300*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	event := waitForEvent(t, events, 5*time.Second, func(e KeyEvent) bool {
+		return e.Key == keyName && e.EventType == EventTypeExpired
+	})
+	if event.OriginalTTL != 300*time.Millisecond {
+		t.Errorf("OriginalTTL: got %s, want 300ms", event.OriginalTTL)
+	}
+	if event.CreatedAt.Before(before.Add(-time.Second)) || event.CreatedAt.After(time.Now()) {
+		t.Errorf("CreatedAt %s is not the write time", event.CreatedAt)
+	}
+}
+
+func TestArchiveExpiredValues(t *testing.T) {
+	v, prefix := newTestRedisGk(t, RedisAdditionalOptions{ArchiveExpiredValues: true})
+	key := testKey(prefix, "session")
+	keyName := testKeyName(t, v, prefix, "session")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := v.SnapshotAndWatch(ctx, prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.SetString(key, "archived value", 300*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	// The key is gone when the event arrives, the value comes from the shadow key
+	event := waitForEvent(t, events, 5*time.Second, func(e KeyEvent) bool {
+		return e.Key == keyName && e.EventType == EventTypeExpired
+	})
+	if event.Value != "archived value" {
+		t.Errorf("expired event value %q, want the archived value", event.Value)
+	}
+}
+
+func TestCleanupOrphans(t *testing.T) {
+	v, prefix := newTestRedisGk(t, RedisAdditionalOptions{ShadowKeys: true})
+	ctx := context.Background()
+
+	orphan := testKeyName(t, v, prefix, "orphan")
+	kept := testKeyName(t, v, prefix, "kept")
+	queue := testKeyName(t, v, prefix, "queue")
+	companions := []string{shadowKeyPrefix + orphan, shadowKeyPrefix + kept, uniqueIDsKeyPrefix + queue}
+	t.Cleanup(func() { v.redisClient.Del(ctx, companions...) })
+
+	if err := v.SetString(testKey(prefix, "orphan"), "a", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString(testKey(prefix, "kept"), "b", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.RPushUnique(testKey(prefix, "queue"), "msg-1", "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Raw deletes bypass the library and leave the companion keys behind
+	if err := v.redisClient.Del(ctx, orphan, queue).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := v.CleanupOrphans(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 2 {
+		t.Errorf("CleanupOrphans deleted %d keys, want 2", deleted)
+	}
+	for key, want := range map[string]int64{companions[0]: 0, companions[1]: 1, companions[2]: 0} {
+		if n, err := v.redisClient.Exists(ctx, key).Result(); err != nil || n != want {
+			t.Errorf("Exists(%s): got %d, %v, want %d", key, n, err, want)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// shardRouter - go-redis hook of the first node sending keyed commands to the node
+// chosen by rendezvous hashing of the key; keyless commands stay on the first node
+type shardRouter struct {
+	// Node identities used for hashing, index 0 - the first node
+	ids []string
+	// Clients of the nodes; commands routed to the first node go down
+	// the hook chain instead, its client here serves Shards views only
+	clients []*redis.Client
+}
+
+// allKeysCommands - commands whose arguments are all keys
+var allKeysCommands = map[string]bool{
+	"mget": true, "del": true, "unlink": true, "exists": true, "touch": true, "watch": true,
+	"sinter": true, "sunion": true, "sdiff": true, "sinterstore": true, "sunionstore": true,
+	"sdiffstore": true, "pfcount": true, "pfmerge": true,
+}
+
+// twoKeysCommands - commands whose first two arguments are keys
+var twoKeysCommands = map[string]bool{
+	"rename": true, "renamenx": true, "copy": true, "lmove": true, "blmove": true,
+	"smove": true, "rpoplpush": true, "brpoplpush": true,
+}
+
+// fanOutCommands - multi-key commands split by node, with results merged back
+var fanOutCommands = map[string]bool{
+	"mget": true, "del": true, "unlink": true, "exists": true, "touch": true,
+}
+
+// NewRedisGkSharded creates an instance spreading keys over several standalone nodes
+// A key is sent to the node chosen by rendezvous (highest random weight) hashing of the
+// normalized key and the node address, so every client with the same node list picks the
+// same node, and adding a node only moves the keys that land on it. Only the part inside
+// {...} is hashed if present, like Redis Cluster hash tags, to keep related keys together.
+// Companion keys (shadow keys, RPushUnique id sets) follow their primary key.
+//
+// The first node is the main one: key events, SCAN-based methods (FindObj, GetKeys,
+// CountKeys, DelByPattern, ExportPrefix, ...) and other keyless commands use it only; use
+// Shards to run pattern methods against every node. MGET, DEL, UNLINK, EXISTS and TOUCH
+// fan out to the nodes, other multi-key commands, transactions and Lua scripts fail with
+// ErrCrossShard unless all their keys are on one node. WATCH transactions (SetObjTimestamped,
+// ...) work only for keys on the first node. AdditionalOptions of the first node apply to all.
+func NewRedisGkSharded(nodes []RedisConfConn) (*RedisGk, error) {
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes provided for NewRedisGkSharded")
+	}
+
+	router := &shardRouter{
+		ids:     make([]string, len(nodes)),
+		clients: make([]*redis.Client, len(nodes)),
+	}
+	seen := make(map[string]bool, len(nodes))
+	for i, node := range nodes {
+		id := fmt.Sprintf("%s/%d", net.JoinHostPort(node.Host, fmt.Sprint(node.Port)), node.DB)
+		if seen[id] {
+			return nil, fmt.Errorf("node %d duplicates %s", i, id)
+		}
+		seen[id] = true
+		router.ids[i] = id
+	}
+
+	// Other nodes share the options of the first one, but not its server setup
+	for i := 1; i < len(nodes); i++ {
+		conf := nodes[i]
+		conf.AdditionalOptions = nodes[0].AdditionalOptions
+
+		client, _, err := newRedisClientConnector(conf)
+		if err != nil {
+			router.close()
+			return nil, fmt.Errorf("node %d: %w", i, err)
+		}
+		client.AddHook(errorHook{})
+		router.clients[i] = client
+	}
+
+	v, err := NewRedisGk(nodes[0])
+	if err != nil {
+		router.close()
+		return nil, err
+	}
+
+	// Client of the first node for Shards views, added before the router hook
+	opts := *v.redisClient.Options()
+	router.clients[0] = redis.NewClient(&opts)
+	router.clients[0].AddHook(errorHook{})
+
+	v.redisClient.AddHook(router)
+	v.shards = router
+	return v, nil
+}
+
+// Shards returns views of the instance bound to each node in the order of
+// NewRedisGkSharded, e.g. to run FindObj or GetKeys on every node
+// Commands of a view go to its node only. A non-sharded instance returns itself.
+func (v *RedisGk) Shards() []*RedisGk {
+	if v == nil {
+		return nil
+	}
+	if v.shards == nil {
+		return []*RedisGk{v}
+	}
+
+	views := make([]*RedisGk, 0, len(v.shards.clients))
+	for i, client := range v.shards.clients {
+		view := v.view()
+		view.redisClient = client
+		view.shards = nil
+		if i > 0 {
+			// Key events belong to the first node
+			view.listenerKeyEventManager = nil
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// close closes clients of the nodes
+func (r *shardRouter) close() error {
+	if r == nil {
+		return nil
+	}
+
+	var errs []error
+	for i, client := range r.clients {
+		if client == nil {
+			continue
+		}
+		if err := client.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("error closing node %d client: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// routingKey returns the part of the key used to choose the node
+func routingKey(key string) string {
+	key = strings.TrimPrefix(key, shadowKeyPrefix)
+	key = strings.TrimPrefix(key, uniqueIDsKeyPrefix)
+
+	// Hash tag: only the non-empty part between the first { and the next }
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			return key[start+1 : start+1+end]
+		}
+	}
+	return key
+}
+
+// node returns the index of the node for the key
+func (r *shardRouter) node(key string) int {
+	keyHash := fnv64a(routingKey(key))
+
+	best := 0
+	var bestScore uint64
+	for i, id := range r.ids {
+		// FNV alone spreads similar keys unevenly, the finalizer mixes all bits
+		if score := mix64(keyHash ^ fnv64a(id)); i == 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// fnv64a returns the 64-bit FNV-1a hash of s
+func fnv64a(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// mix64 - MurmurHash3 64-bit finalizer
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// commandKeys returns the key arguments of a command
+func commandKeys(cmd redis.Cmder) []string {
+	args := cmd.Args()
+	name := strings.ToLower(cmd.Name())
+
+	var keys []any
+	switch {
+	case allKeysCommands[name] && len(args) > 1:
+		keys = args[1:]
+	case twoKeysCommands[name] && len(args) > 2:
+		keys = args[1:3]
+	case (name == "mset" || name == "msetnx") && len(args) > 1:
+		for i := 1; i < len(args); i += 2 {
+			keys = append(keys, args[i])
+		}
+	default:
+		numKeysAt := 0
+		switch name {
+		case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+			numKeysAt = 2
+		case "lmpop", "zmpop", "sintercard", "zintercard":
+			numKeysAt = 1
+		}
+		if numKeysAt == 0 {
+			if key := commandKey(cmd); key != "" {
+				return []string{key}
+			}
+			return nil
+		}
+		if len(args) <= numKeysAt {
+			return nil
+		}
+		var numKeys int
+		if _, err := fmt.Sscan(fmt.Sprint(args[numKeysAt]), &numKeys); err != nil {
+			return nil
+		}
+		end := min(numKeysAt+1+numKeys, len(args))
+		keys = args[numKeysAt+1 : end]
+	}
+
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, fmt.Sprint(key))
+	}
+	return result
+}
+
+// commandNode returns the node of all keys of the command, -1 for a keyless command
+func (r *shardRouter) commandNode(cmd redis.Cmder) (int, error) {
+	node := -1
+	for _, key := range commandKeys(cmd) {
+		n := r.node(key)
+		if node >= 0 && n != node {
+			return 0, fmt.Errorf("%w: keys of %s are on different nodes", ErrCrossShard, cmd.Name())
+		}
+		node = n
+	}
+	return node, nil
+}
+
+// process runs the command on the node
+func (r *shardRouter) process(ctx context.Context, next redis.ProcessHook, node int, cmd redis.Cmder) error {
+	if node <= 0 {
+		return next(ctx, cmd)
+	}
+	return r.clients[node].Process(ctx, cmd)
+}
+
+// DialHook passes dialing through unchanged
+func (r *shardRouter) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook sends the command to the node of its keys
+func (r *shardRouter) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		name := strings.ToLower(cmd.Name())
+		if fanOutCommands[name] {
+			return r.fanOut(ctx, next, cmd)
+		}
+
+		node, err := r.commandNode(cmd)
+		if err == nil && name == "watch" && node > 0 {
+			err = fmt.Errorf("%w: WATCH transactions need keys on the first node", ErrCrossShard)
+		}
+		if err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		return r.process(ctx, next, node, cmd)
+	}
+}
+
+// fanOut splits a multi-key command by node and merges the replies
+func (r *shardRouter) fanOut(ctx context.Context, next redis.ProcessHook, cmd redis.Cmder) error {
+	keys := commandKeys(cmd)
+	name := strings.ToLower(cmd.Name())
+
+	// Positions of the keys grouped by node
+	groups := make(map[int][]int)
+	for i, key := range keys {
+		n := r.node(key)
+		groups[n] = append(groups[n], i)
+	}
+	if len(groups) <= 1 {
+		node := 0
+		for n := range groups {
+			node = n
+		}
+		return r.process(ctx, next, node, cmd)
+	}
+
+	values := make([]any, len(keys))
+	var total int64
+	for node, positions := range groups {
+		args := make([]any, 0, len(positions)+1)
+		args = append(args, name)
+		for _, i := range positions {
+			args = append(args, keys[i])
+		}
+
+		if name == "mget" {
+			sub := redis.NewSliceCmd(ctx, args...)
+			if err := r.process(ctx, next, node, sub); err != nil {
+				cmd.SetErr(err)
+				return err
+			}
+			for j, value := range sub.Val() {
+				if j < len(positions) {
+					values[positions[j]] = value
+				}
+			}
+			continue
+		}
+
+		sub := redis.NewIntCmd(ctx, args...)
+		if err := r.process(ctx, next, node, sub); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		total += sub.Val()
+	}
+
+	switch c := cmd.(type) {
+	case *redis.SliceCmd:
+		c.SetVal(values)
+	case *redis.IntCmd:
+		c.SetVal(total)
+	default:
+		err := fmt.Errorf("%w: unexpected reply type of %s", ErrCrossShard, name)
+		cmd.SetErr(err)
+		return err
+	}
+	return nil
+}
+
+// ProcessPipelineHook sends pipelined commands to their nodes
+// A transaction (MULTI/EXEC) must have all keys on one node
+func (r *shardRouter) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		isTx := len(cmds) > 0 && strings.EqualFold(cmds[0].Name(), "multi")
+
+		nodes := make([]int, len(cmds))
+		groups := make(map[int][]redis.Cmder)
+		for i, cmd := range cmds {
+			node, err := r.commandNode(cmd)
+			if err != nil {
+				setCmdsErr(cmds, err)
+				return err
+			}
+			nodes[i] = max(node, 0)
+			if node >= 0 {
+				groups[node] = append(groups[node], cmd)
+			}
+		}
+
+		if isTx {
+			if len(groups) > 1 {
+				err := fmt.Errorf("%w: keys of a transaction are on different nodes", ErrCrossShard)
+				setCmdsErr(cmds, err)
+				return err
+			}
+			for node := range groups {
+				if node > 0 {
+					// Commands without MULTI/EXEC, the node's TxPipeline wraps them again
+					return r.execPipeline(ctx, r.clients[node].TxPipeline(), cmds[1:len(cmds)-1])
+				}
+			}
+			return next(ctx, cmds)
+		}
+
+		if len(groups) <= 1 {
+			for node := range groups {
+				if node > 0 {
+					return r.execPipeline(ctx, r.clients[node].Pipeline(), cmds)
+				}
+			}
+			return next(ctx, cmds)
+		}
+
+		// Keyless commands of a mixed pipeline go to the first node
+		var first []redis.Cmder
+		for i, cmd := range cmds {
+			if nodes[i] == 0 {
+				first = append(first, cmd)
+			}
+		}
+
+		var firstErr error
+		if len(first) > 0 {
+			firstErr = next(ctx, first)
+		}
+		for node, group := range groups {
+			if node == 0 {
+				continue
+			}
+			if err := r.execPipeline(ctx, r.clients[node].Pipeline(), group); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+}
+
+// execPipeline queues the commands into the pipeline of another node and runs it
+func (r *shardRouter) execPipeline(ctx context.Context, pipe redis.Pipeliner, cmds []redis.Cmder) error {
+	for _, cmd := range cmds {
+		if err := pipe.Process(ctx, cmd); err != nil {
+			return err
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// setCmdsErr sets the error on all commands
+func setCmdsErr(cmds []redis.Cmder, err error) {
+	for _, cmd := range cmds {
+		cmd.SetErr(err)
+	}
+}
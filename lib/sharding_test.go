@@ -0,0 +1,139 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestShardRouterNodeDeterministic(t *testing.T) {
+	ids := []string{"10.0.0.1:6379/0", "10.0.0.2:6379/0", "10.0.0.3:6379/0"}
+	r := &shardRouter{ids: ids}
+	same := &shardRouter{ids: append([]string(nil), ids...)}
+
+	counts := make([]int, len(ids))
+	for i := range 3000 {
+		key := fmt.Sprintf("users:%d", i)
+		n := r.node(key)
+		if n != same.node(key) || n != r.node(key) {
+			t.Fatalf("key %s placed on different nodes", key)
+		}
+		counts[n]++
+	}
+	for i, c := range counts {
+		if c < 800 || c > 1200 {
+			t.Errorf("node %d got %d of 3000 keys, want a roughly even spread", i, c)
+		}
+	}
+
+	if r.node(shadowKeyPrefix+"users:7") != r.node("users:7") ||
+		r.node(uniqueIDsKeyPrefix+"users:7") != r.node("users:7") {
+		t.Error("companion keys don't follow their primary key")
+	}
+	if r.node("orders:{user1}:a") != r.node("carts:{user1}:b") {
+		t.Error("keys with the same hash tag are on different nodes")
+	}
+}
+
+func TestShardRouterAddNodeMovesOnlyToNewNode(t *testing.T) {
+	r := &shardRouter{ids: []string{"a:6379/0", "b:6379/0", "c:6379/0"}}
+	grown := &shardRouter{ids: append(append([]string(nil), r.ids...), "d:6379/0")}
+
+	for i := range 2000 {
+		key := fmt.Sprintf("k:%d", i)
+		before, after := r.node(key), grown.node(key)
+		if before != after && after != 3 {
+			t.Fatalf("key %s moved from node %d to existing node %d", key, before, after)
+		}
+	}
+}
+
+func TestShardRouterCrossShardCommand(t *testing.T) {
+	r := &shardRouter{ids: []string{"a:6379/0", "b:6379/0"}}
+
+	// Find two keys on different nodes
+	other := ""
+	for i := range 100 {
+		key := fmt.Sprintf("k:%d", i)
+		if r.node(key) != r.node("k:base") {
+			other = key
+			break
+		}
+	}
+	if other == "" {
+		t.Fatal("no key found on the second node")
+	}
+
+	ctx := context.Background()
+	_, err := r.commandNode(redis.NewStatusCmd(ctx, "rename", "k:base", other))
+	if !errors.Is(err, ErrCrossShard) {
+		t.Errorf("rename across nodes: got %v, want ErrCrossShard", err)
+	}
+	node, err := r.commandNode(redis.NewStatusCmd(ctx, "ping"))
+	if err != nil || node != -1 {
+		t.Errorf("keyless command: got node %d, %v, want -1", node, err)
+	}
+}
+
+func TestNewRedisGkShardedRoutesKeys(t *testing.T) {
+	servers := []*fakeServer{newFakeServer(t, "secret"), newFakeServer(t, "secret")}
+	nodes := []RedisConfConn{servers[0].conf("secret"), servers[1].conf("secret")}
+	nodes[0].AdditionalOptions.CircuitBreakerThreshold = 2
+
+	v, err := NewRedisGkSharded(nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	perNode := make([]int, len(servers))
+	for i := range 20 {
+		key := fmt.Sprintf("k:%d", i)
+		if err := v.SetString([]string{"k", fmt.Sprint(i)}, "v"+fmt.Sprint(i)); err != nil {
+			t.Fatal(err)
+		}
+		n := v.shards.node(key)
+		perNode[n]++
+
+		// Stored on the chosen node only, and read back through the router
+		servers[n].mu.Lock()
+		_, onChosen := servers[n].data[key]
+		servers[n].mu.Unlock()
+		servers[1-n].mu.Lock()
+		_, onOther := servers[1-n].data[key]
+		servers[1-n].mu.Unlock()
+		if !onChosen || onOther {
+			t.Errorf("key %s: on node %d %v, on the other %v", key, n, onChosen, onOther)
+		}
+		if got, err := v.GetString([]string{"k", fmt.Sprint(i)}); err != nil || got != "v"+fmt.Sprint(i) {
+			t.Errorf("GetString(%s): got %q, %v", key, got, err)
+		}
+	}
+	if perNode[0] == 0 || perNode[1] == 0 {
+		t.Errorf("keys per node %v, want both nodes used", perNode)
+	}
+
+	other := ""
+	for i := range 100 {
+		key := fmt.Sprintf("k:%d", i)
+		if v.shards.node(key) != v.shards.node("k:0") {
+			other = key
+			break
+		}
+	}
+
+	// Rejected by the router, not a connection failure that opens the circuit of the key
+	ctx := context.Background()
+	for range 3 {
+		err := v.redisClient.Rename(ctx, "k:0", other).Err()
+		if !errors.Is(err, ErrCrossShard) || errors.Is(err, ErrConnection) {
+			t.Fatalf("rename across nodes: got %v, want only ErrCrossShard", err)
+		}
+	}
+	if got, err := v.GetString([]string{"k", "0"}); err != nil || got != "v0" {
+		t.Errorf("GetString after cross-shard commands: got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,99 @@
+package redisgklib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TimestampedObj - object stored together with its write timestamps
+type TimestampedObj[T any] struct {
+	Value     T         `json:"value"`
+	CreatedAt time.Time `json:"created_at"` // Time of the first write
+	UpdatedAt time.Time `json:"updated_at"` // Time of the last write
+}
+
+// timestampedMaxRetries - attempts of the optimistic transaction on concurrent writes
+const timestampedMaxRetries = 5
+
+// SetObjTimestamped saves object wrapped into TimestampedObj
+// CreatedAt is kept from the stored value on rewrite, UpdatedAt is set to the current time.
+// The read and write run in a WATCH transaction, so concurrent writers don't lose CreatedAt.
+// MaxValueSize, prefix quotas and shadow keys apply like in SetObj.
+func SetObjTimestamped[T any](
+	v *RedisGk,
+	keyPath []string,
+	value T,
+	ttlSlice ...time.Duration,
+) error {
+	if v == nil {
+		return fmt.Errorf("RedisGk instance is nil")
+	}
+
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keyP, err := v.slicePathsConvertor(keyPath)
+	if err != nil {
+		return fmt.Errorf("key conversion error: %w", err)
+	}
+
+	ttl := v.resolveTTL(ttlSlice)
+
+	txf := func(tx *redis.Tx) error {
+		now := time.Now().UTC()
+		wrapped := TimestampedObj[T]{
+			Value:     value,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		stored, err := tx.Get(ctx, keyP).Result()
+		if err != nil && err != redis.Nil {
+			return fmt.Errorf("error getting key %s: %w", keyP, err)
+		}
+		if err == nil {
+			var previous TimestampedObj[T]
+			if v.unmarshalValue([]byte(stored), &previous) == nil && !previous.CreatedAt.IsZero() {
+				wrapped.CreatedAt = previous.CreatedAt
+			}
+		}
+
+		jsonData, err := v.encodeValue(wrapped)
+		if err != nil {
+			return fmt.Errorf("object serialization error: %w", err)
+		}
+
+		if err := v.checkValueSize(jsonData); err != nil {
+			return err
+		}
+		if err := v.checkQuota(ctx, keyP); err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			v.queueStore(ctx, pipe, keyP, jsonData, ttl)
+			return nil
+		})
+		return err
+	}
+
+	for range timestampedMaxRetries {
+		err = v.redisClient.Watch(ctx, txf, keyP)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("error saving key %s: too many concurrent writes", keyP)
+}
+
+// GetObjTimestamped gets object saved by SetObjTimestamped together with its timestamps
+func GetObjTimestamped[T any](
+	v *RedisGk,
+	keyPath []string,
+) (*TimestampedObj[T], error) {
+	return GetObj[TimestampedObj[T]](v, keyPath)
+}
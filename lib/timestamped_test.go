@@ -0,0 +1,81 @@
+package redisgklib
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObjTimestamped(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+	key := testKey(prefix, "audit")
+
+	before := time.Now().UTC().Add(-time.Second)
+	if err := SetObjTimestamped(v, key, listItem{ID: 1}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	first, err := GetObjTimestamped[listItem](v, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Value.ID != 1 {
+		t.Errorf("value %+v, want ID 1", first.Value)
+	}
+	if first.CreatedAt.Before(before) || !first.UpdatedAt.Equal(first.CreatedAt) {
+		t.Errorf("first write timestamps created %s updated %s", first.CreatedAt, first.UpdatedAt)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if err := SetObjTimestamped(v, key, listItem{ID: 2}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	second, err := GetObjTimestamped[listItem](v, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Value.ID != 2 {
+		t.Errorf("value after rewrite %+v, want ID 2", second.Value)
+	}
+	if !second.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("CreatedAt changed on rewrite: %s, was %s", second.CreatedAt, first.CreatedAt)
+	}
+	if !second.UpdatedAt.After(first.UpdatedAt) {
+		t.Errorf("UpdatedAt %s not after the first write %s", second.UpdatedAt, first.UpdatedAt)
+	}
+}
+
+func TestObjTimestampedSizeQuotaAndShadow(t *testing.T) {
+	v, prefix := newTestRedisGk(t, RedisAdditionalOptions{MaxValueSize: 256, ShadowKeys: true})
+	ctx := context.Background()
+
+	large := listItem{ID: 1, Created: time.Now()}
+	type note struct {
+		Text string `json:"text"`
+	}
+	if err := SetObjTimestamped(v, testKey(prefix, "large"), note{Text: strings.Repeat("x", 300)}); err == nil {
+		t.Error("value over MaxValueSize was stored")
+	}
+	if err := SetObjTimestamped(v.SkipSizeCheck(), testKey(prefix, "large"), note{Text: strings.Repeat("x", 300)}); err != nil {
+		t.Errorf("SkipSizeCheck: %v", err)
+	}
+
+	tenant := testKey(prefix, "tenant")
+	if err := v.SetPrefixQuota(tenant, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetObjTimestamped(v, testKey(tenant, "a"), large, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetObjTimestamped(v, testKey(tenant, "b"), large, time.Minute); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("key over the quota: got %v, want ErrQuotaExceeded", err)
+	}
+
+	shadow := shadowKeyPrefix + testKeyName(t, v, tenant, "a")
+	t.Cleanup(func() { v.redisClient.Del(ctx, shadow) })
+	fields, err := v.redisClient.HGetAll(ctx, shadow).Result()
+	if err != nil || fields["ttl"] != "60000" {
+		t.Errorf("shadow key %v, %v, want the original TTL", fields, err)
+	}
+}
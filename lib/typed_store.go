@@ -0,0 +1,86 @@
+package redisgklib
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TypedStore - objects of one type stored under a common key prefix
+// Keys are prefix:id (with KeySeparator), values are JSON as written by SetObj
+type TypedStore[T any] struct {
+	v      *RedisGk
+	prefix []string
+}
+
+// Typed returns a store of T objects under the prefix
+func Typed[T any](v *RedisGk, prefix []string) *TypedStore[T] {
+	return &TypedStore[T]{
+		v:      v,
+		prefix: append([]string(nil), prefix...),
+	}
+}
+
+// keyPath returns the key path of the object with the id
+func (s *TypedStore[T]) keyPath(id string) []string {
+	return append(append([]string(nil), s.prefix...), id)
+}
+
+// Set saves the object with the id
+func (s *TypedStore[T]) Set(id string, value T, ttlSlice ...time.Duration) error {
+	if id == "" {
+		return fmt.Errorf("id is empty")
+	}
+	return SetObj(s.v, s.keyPath(id), value, ttlSlice...)
+}
+
+// Get gets the object with the id
+func (s *TypedStore[T]) Get(id string) (*T, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id is empty")
+	}
+	return GetObj[T](s.v, s.keyPath(id))
+}
+
+// Delete deletes the object with the id
+func (s *TypedStore[T]) Delete(id string) error {
+	if id == "" {
+		return fmt.Errorf("id is empty")
+	}
+	return s.v.Del(s.keyPath(id))
+}
+
+// Find searches objects whose id starts with the pattern (empty - all objects of the store)
+// Results are keyed by id
+func (s *TypedStore[T]) Find(pattern string) (map[string]*T, error) {
+	if s.v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+
+	patternPath := s.prefix
+	if pattern != "" {
+		patternPath = s.keyPath(pattern)
+	}
+
+	prefix, err := s.v.normalizeKeyPath(s.prefix)
+	if err != nil {
+		return nil, fmt.Errorf("pattern conversion error: %w", err)
+	}
+
+	found, err := FindObj[T](s.v, patternPath)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*T, len(found))
+	for key, obj := range found {
+		id, ok := strings.CutPrefix(key, prefix+s.v.separator())
+		if !ok {
+			// Key of a longer prefix sharing the same start, e.g. users2:1 for users
+			continue
+		}
+		results[id] = obj
+	}
+
+	return results, nil
+}
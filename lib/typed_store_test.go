@@ -0,0 +1,59 @@
+package redisgklib
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTypedStore(t *testing.T) {
+	v, fake := newFakeRedisGk(t)
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	users := Typed[user](v, []string{"users"})
+	admins := Typed[user](v, []string{"users2"})
+
+	for id, name := range map[string]string{"1": "Alice", "12": "Bob", "2": "Carol"} {
+		if err := users.Set(id, user{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := admins.Set("1", user{Name: "Root"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fake.get("users:12"); !ok {
+		t.Error("object not stored under prefix:id")
+	}
+
+	got, err := users.Get("12")
+	if err != nil || got.Name != "Bob" {
+		t.Errorf("Get: got %+v, %v", got, err)
+	}
+
+	// Ids starting with 1, keys of the users2 store sharing the prefix are not included
+	found, err := users.Find("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 2 || found["1"].Name != "Alice" || found["12"].Name != "Bob" {
+		t.Errorf("Find(\"1\"): got %v", found)
+	}
+	all, err := users.Find("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Errorf("Find(\"\") found %d objects, want 3", len(all))
+	}
+
+	if err := users.Delete("12"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.Get("12"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get after Delete: got %v, want ErrKeyNotFound", err)
+	}
+	if _, err := users.Get(""); err == nil {
+		t.Error("Get with an empty id succeeded")
+	}
+}
@@ -1,6 +1,8 @@
 package redisgklib
 
 import (
+	"context"
+	"crypto/tls"
 	"time"
 )
 
@@ -11,6 +13,10 @@ type RedisConfConn struct {
 	Password string
 	DB       int
 
+	// CredentialsProvider returns credentials for every new connection, e.g. short-lived
+	// IAM auth tokens. When set, User and Password are ignored and not required.
+	CredentialsProvider func() (user, password string, err error)
+
 	AdditionalOptions RedisAdditionalOptions
 }
 
@@ -20,8 +26,205 @@ type RedisAdditionalOptions struct {
 	WriteTimeout time.Duration
 	PoolSize     int
 	PoolTimeout  time.Duration
+	// TLSConfig enables TLS for connections to Redis (nil - plain TCP)
+	TLSConfig *tls.Config
 
+	// BaseCtx - timeout of one library call, WithTimeout overrides it per call (0 - 10s)
+	// A call may run several commands (e.g. FindObj runs SCAN and MGET per batch),
+	// each of them is also bounded by ReadTimeout/WriteTimeout unless ContextTimeouts is set.
 	BaseCtx time.Duration
+	// ContextTimeouts makes socket reads and writes follow the call context instead of
+	// ReadTimeout/WriteTimeout, so WithTimeout can extend a single long command
+	ContextTimeouts bool
+
+	// SkipServerSetup skips the startup ping, CONFIG SET of notify-keyspace-events and the
+	// key event subscription, e.g. for mocks implementing only data commands.
+	// Key events, SnapshotAndWatch and StartupRetries are not available.
+	SkipServerSetup bool
+	// StartupRetries - additional connection attempts in NewRedisGk if Redis is not up yet
+	StartupRetries int
+	// StartupRetryDelay - delay before the first retry, doubled after each attempt up to 30s (0 - 1s)
+	StartupRetryDelay time.Duration
+
+	// ShadowKeys enables companion "shadow" keys that record the creation time
+	// and original TTL of values written with a TTL, so that expired events can
+	// carry OriginalTTL and CreatedAt. Costs one extra hash write per TTL write.
+	ShadowKeys bool
+	// ArchiveExpiredValues also copies values written with a TTL into the shadow key,
+	// so that expired events carry the value of the vanished key. Implies ShadowKeys
+	// and doubles the memory of such values until the shadow key expires.
+	ArchiveExpiredValues bool
+
+	// DisableHTMLEscape stores <, > and & in JSON strings as is instead of \u003c-style escapes
+	DisableHTMLEscape bool
+	// JSONIndent indents stored JSON with the given string (empty - compact JSON)
+	JSONIndent string
+
+	// StrictKeyValidation rejects key paths with an element that is empty after
+	// normalization (e.g. "..."), reporting its index, instead of silently dropping it
+	StrictKeyValidation bool
+	// MaxKeyLength - normalized keys longer than this are replaced with their start and
+	// the SHA-256 of the whole key, keeping the same length (0 - disabled, otherwise >= 80)
+	// Patterns longer than MaxKeyLength don't match hashed keys
+	MaxKeyLength int
+	// KeySeparator joins key path elements and is collapsed and trimmed by normalization (empty - ":")
+	KeySeparator string
+	// StrictMode makes GetRedisClient return nil, so key normalization and size checks
+	// can't be bypassed with the raw client
+	StrictMode bool
+
+	// MaxValueSize limits values stored by SetString and SetObj in bytes (0 - Redis limit 512 MB)
+	MaxValueSize int
+	// OnOversize - SetString behavior for values over MaxValueSize (empty - OversizeError)
+	// Objects always fail since truncated JSON can't be read back
+	OnOversize OversizePolicy
+	// CompressionThreshold - objects serialized to more bytes are stored gzip-compressed by
+	// typed helpers (SetObj, LPushObj, SAddObj, SetMap, ...) and decompressed on read (0 - disabled)
+	CompressionThreshold int
+	// TypeTags - typed helpers store the Go type name with each object and reads fail with
+	// ErrTypeMismatch when it differs from the requested type (untagged objects are read as is)
+	TypeTags bool
+	// Encryptor encrypts values of typed helpers, SetString and SetBytes before they are stored
+	// and decrypts them on read (nil - disabled). MaxValueSize applies to the ciphertext.
+	// SetInt and SetFloat values stay plain, so INCR and CompareAndSetInt keep working
+	Encryptor Encryptor
+
+	// NoMatchError - pattern searches (FindObj, FindObjDetailed, FindRaw, FindKeyByPattern) fail
+	// with ErrNoMatch when nothing is found; by default they return empty results and no error
+	NoMatchError bool
+
+	// DefaultTTL is applied by SetObj and SetString when TTL is omitted or zero (0 - no expiration)
+	DefaultTTL time.Duration
+
+	// EventQueueSize - buffer between Redis subscription and event consumers (0 - 1000)
+	EventQueueSize int
+	// EventOverflowPolicy - behavior when the event buffer is full (empty - OverflowDropNewest)
+	EventOverflowPolicy OverflowPolicy
+	// EventQueueHighWater - queued events at which a backlog warning is logged and reported
+	// to a MetricsCollector implementing EventQueueMetricsCollector (0 - 80% of EventQueueSize)
+	EventQueueHighWater int
+	// EventDBs - databases whose key events are delivered, each event carries its DB
+	// (empty - only the configured DB). notify-keyspace-events is server-wide, one setup covers all
+	EventDBs []int
+
+	// Logger receives library log messages (nil - messages are discarded)
+	Logger Logger
+	// MetricsCollector receives library metrics (nil - metrics are not collected)
+	MetricsCollector MetricsCollector
+	// SlowOpThreshold - commands taking longer are reported to Logger and MetricsCollector (0 - disabled)
+	// Blocking commands like BLMove include their wait time
+	SlowOpThreshold time.Duration
+	// CircuitBreakerThreshold - consecutive connection failures on a key that open its circuit,
+	// failing commands with ErrCircuitOpen without contacting Redis (0 - disabled)
+	CircuitBreakerThreshold int
+	// CircuitBreakerWindow - failures further apart start a new count (0 - no limit)
+	CircuitBreakerWindow time.Duration
+	// CircuitBreakerCooldown - time the circuit stays open before a probe command (0 - 5s)
+	CircuitBreakerCooldown time.Duration
+	// HealthCheckInterval enables background ping with listener reconnect on recovery (0 - disabled)
+	HealthCheckInterval time.Duration
+}
+
+// MigrateFunc converts stored data of an older object format into the current one
+type MigrateFunc func(raw []byte) ([]byte, error)
+
+// OverflowPolicy - behavior of the event listener when consumers fall behind
+type OverflowPolicy string
+
+const (
+	OverflowDropNewest OverflowPolicy = "drop_newest" // Discard incoming events
+	OverflowDropOldest OverflowPolicy = "drop_oldest" // Discard the oldest queued event
+	OverflowBlock      OverflowPolicy = "block"       // Stop draining the subscription until there is room
+)
+
+// MetricsCollector - interface for library metrics
+type MetricsCollector interface {
+	// ObserveSlowOp is called for a command exceeding SlowOpThreshold
+	// Pipelines and transactions are reported as one "pipeline" command
+	ObserveSlowOp(ctx context.Context, command, key string, duration time.Duration)
+}
+
+// EventQueueMetricsCollector - optionally implemented by a MetricsCollector to be told
+// when event consumers fall behind
+type EventQueueMetricsCollector interface {
+	// ObserveEventQueueHighWater is called when the event queue reaches EventQueueHighWater
+	// It is called again only after the queue drains below half of the mark
+	ObserveEventQueueHighWater(ctx context.Context, depth, capacity int)
+}
+
+// OversizePolicy - behavior of SetString for values over MaxValueSize
+type OversizePolicy string
+
+const (
+	OversizeError    OversizePolicy = "error"    // Reject the value
+	OversizeTruncate OversizePolicy = "truncate" // Store the first MaxValueSize bytes and log it
+)
+
+// Logger - interface for library log messages
+type Logger interface {
+	Printf(ctx context.Context, format string, args ...any)
+}
+
+// Encryptor - encryption of values at rest, see RedisAdditionalOptions.Encryptor
+// NewAESGCMEncryptor returns the default AES-GCM implementation
+type Encryptor interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// HealthStatus - connection state reported by Health
+type HealthStatus struct {
+	Healthy             bool      `json:"healthy"`              // Last ping succeeded
+	LastCheck           time.Time `json:"last_check"`           // Time of the last ping
+	LastError           error     `json:"-"`                    // Error of the last failed ping
+	ConsecutiveFailures int       `json:"consecutive_failures"` // Failed pings in a row
+
+	Circuit      CircuitState `json:"circuit,omitempty"`       // Most severe circuit breaker state over all keys (empty - disabled)
+	OpenCircuits []string     `json:"open_circuits,omitempty"` // Keys whose circuit is open or half open ("" - keyless commands)
+}
+
+// CircuitState - state of the circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // Commands are sent to Redis
+	CircuitOpen     CircuitState = "open"      // Commands fail with ErrCircuitOpen
+	CircuitHalfOpen CircuitState = "half_open" // A probe command decides whether to close
+)
+
+// List ends for LMove and BLMove
+const (
+	ListEndLeft  = "LEFT"
+	ListEndRight = "RIGHT"
+)
+
+// Redis key types for type filters
+const (
+	KeyTypeString = "string"
+	KeyTypeList   = "list"
+	KeyTypeSet    = "set"
+	KeyTypeZSet   = "zset"
+	KeyTypeHash   = "hash"
+	KeyTypeStream = "stream"
+)
+
+// KeyInfo - existence, remaining TTL and type of a key returned by InspectMany
+type KeyInfo struct {
+	Exists bool          `json:"exists"`
+	TTL    time.Duration `json:"ttl"`  // Remaining TTL, 0 - no expiration or no key
+	Type   string        `json:"type"` // One of KeyType... constants, empty if the key doesn't exist
+}
+
+// SortOptions - options of Sort
+// By and Get are Redis patterns, not key paths: "*" is replaced by the element, and
+// "->field" reads a hash field, e.g. "weight:*" or "user:*->name"; "#" in Get is the element itself
+type SortOptions struct {
+	By     string   // Sort by values of the keys matching the pattern, "nosort" - keep the order
+	Get    []string // Return values of the keys matching the patterns instead of the elements
+	Offset int64    // Number of elements to skip
+	Count  int64    // Maximum number of elements (0 - all)
+	Alpha  bool     // Compare elements as strings instead of numbers
+	Desc   bool     // Sort in descending order
 }
 
 // EventType - Redis event type
@@ -34,6 +237,9 @@ const (
 	EventTypeUpdated EventType = "updated" // Key updated
 	EventTypeDeleted EventType = "deleted" // Key deleted
 	EventTypeUnknown EventType = "unknown" // Unknown event type
+
+	EventTypeRenamedFrom EventType = "renamed_from" // Key renamed, Key holds the old name
+	EventTypeRenamedTo   EventType = "renamed_to"   // Key renamed, Key holds the new name
 )
 
 // KeyEvent - structure for Redis key event
@@ -43,4 +249,9 @@ type KeyEvent struct {
 	EventType EventType `json:"event_type"` // Event type
 	Timestamp time.Time `json:"timestamp"`  // Event timestamp
 	Channel   string    `json:"channel"`    // Channel name
+	DB        int       `json:"db"`         // Database index the event came from
+
+	// Filled for expired events only when ShadowKeys or ArchiveExpiredValues is enabled
+	OriginalTTL time.Duration `json:"original_ttl"` // TTL the key was written with
+	CreatedAt   time.Time     `json:"created_at"`   // Time the key was written
 }
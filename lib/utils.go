@@ -2,10 +2,14 @@ package redisgklib
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 // createContextWithTimeout creates context with timeout for Redis operations
@@ -14,11 +18,73 @@ func (v *RedisGk) createContextWithTimeout() (context.Context, context.CancelFun
 		// Return context with default timeout if instance is nil
 		return context.WithTimeout(context.Background(), 10*time.Second)
 	}
-	return context.WithTimeout(context.Background(), v.baseCtx)
+	return context.WithTimeout(v.parentContext(), v.baseCtx)
 }
 
-// pathRedisController normalizes key for Redis
-func pathRedisController(key string) string {
+// createContextWithExtraTimeout creates context for blocking Redis operations,
+// adding the blocking time to the base timeout
+func (v *RedisGk) createContextWithExtraTimeout(extra time.Duration) (context.Context, context.CancelFunc) {
+	if v == nil {
+		return context.WithTimeout(context.Background(), 10*time.Second+extra)
+	}
+	return context.WithTimeout(v.parentContext(), v.baseCtx+extra)
+}
+
+// parentContext returns the context operation contexts are derived from
+func (v *RedisGk) parentContext() context.Context {
+	if v.parentCtx != nil {
+		return v.parentCtx
+	}
+	return context.Background()
+}
+
+// resolveTTL returns TTL passed to a write method, or DefaultTTL if it is omitted or zero
+func (v *RedisGk) resolveTTL(ttlSlice []time.Duration) time.Duration {
+	ttl := time.Duration(0)
+	if len(ttlSlice) > 0 {
+		ttl = ttlSlice[0]
+	}
+	if ttl == 0 {
+		ttl = v.defaultTTL
+	}
+	return ttl
+}
+
+// waitWithTimeout waits for the WaitGroup at most timeout (0 - no limit)
+// Returns false if the timeout expired first
+func waitWithTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	if timeout <= 0 {
+		wg.Wait()
+		return true
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// logf writes message to the logger if it is set
+func logf(logger Logger, ctx context.Context, format string, args ...any) {
+	if logger == nil {
+		return
+	}
+	logger.Printf(ctx, format, args...)
+}
+
+// pathRedisController normalizes key for Redis, sep is the key path separator
+func pathRedisController(key, sep string) string {
 	if key == "" {
 		return ""
 	}
@@ -29,15 +95,20 @@ func pathRedisController(key string) string {
 	re01 := regexp.MustCompile(`[\?\[\]\.]`)
 	keys = re01.ReplaceAllString(keys, "")
 
-	// Replace multiple colons with single one
-	re02 := regexp.MustCompile(`:{2,}`)
-	keys = re02.ReplaceAllString(keys, ":")
+	// Replace multiple separators with single one
+	re02 := regexp.MustCompile(`(?:` + regexp.QuoteMeta(sep) + `){2,}`)
+	keys = re02.ReplaceAllString(keys, sep)
 
 	// Replace spaces with underscores
 	keys = strings.ReplaceAll(keys, " ", "_")
 
-	// Remove colons at beginning and end
-	keys = strings.Trim(keys, ":")
+	// Remove separators at beginning and end
+	for strings.HasPrefix(keys, sep) {
+		keys = keys[len(sep):]
+	}
+	for strings.HasSuffix(keys, sep) {
+		keys = keys[:len(keys)-len(sep)]
+	}
 
 	// Check for maximum key length
 	if len(keys) > maxSizeData {
@@ -48,8 +119,54 @@ func pathRedisController(key string) string {
 	return keys
 }
 
+// defaultKeySeparator - separator of key path elements unless KeySeparator is set
+const defaultKeySeparator = ":"
+
+// separator returns the key path separator
+func (v *RedisGk) separator() string {
+	if v == nil || v.keySeparator == "" {
+		return defaultKeySeparator
+	}
+	return v.keySeparator
+}
+
+// validateKeySeparator checks that key normalization keeps the separator intact
+func validateKeySeparator(sep string) error {
+	if sep == "" {
+		return nil
+	}
+	if strings.ContainsAny(sep, "?[].* ") || sep != strings.ToLower(sep) {
+		return fmt.Errorf("invalid KeySeparator %q: must not contain ?, [, ], ., *, spaces or uppercase letters", sep)
+	}
+	return nil
+}
+
 // slicePathsConvertor converts string slice to Redis key path
-func slicePathsConvertor(keySlice []string) (string, error) {
+func (v *RedisGk) slicePathsConvertor(keySlice []string) (string, error) {
+	keyPath, err := v.normalizeKeyPath(keySlice)
+	if err != nil {
+		return "", err
+	}
+
+	return v.hashLongKey(keyPath), nil
+}
+
+// prefixPatternConvertor converts string slice to a SCAN pattern matching every key
+// written with a path starting with the same elements
+// The prefix is normalized exactly like keys by slicePathsConvertor, but never hashed,
+// so a long prefix still matches the keys under it. Keys hashed by MaxKeyLength only
+// match prefixes within their readable start.
+func (v *RedisGk) prefixPatternConvertor(patternPath []string) (string, error) {
+	prefix, err := v.normalizeKeyPath(patternPath)
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + "*", nil
+}
+
+// normalizeKeyPath validates the slice and joins it into a normalized key path
+func (v *RedisGk) normalizeKeyPath(keySlice []string) (string, error) {
 	if keySlice == nil {
 		return "", fmt.Errorf("keySlice is nil")
 	}
@@ -63,14 +180,20 @@ func slicePathsConvertor(keySlice []string) (string, error) {
 		if key == "" {
 			return "", fmt.Errorf("element %d in keySlice is empty", i)
 		}
+		if v != nil && v.strictKeyValidation && pathRedisController(key, v.separator()) == "" {
+			return "", fmt.Errorf("element %d (%q) in keySlice is empty after normalization", i, key)
+		}
 	}
 
-	keyPath := strings.Join(keySlice, ":")
-	keyPath = pathRedisController(keyPath)
+	keyPath := strings.Join(keySlice, v.separator())
+	keyPath = pathRedisController(keyPath, v.separator())
 
 	// Check result after normalization
 	if keyPath == "" {
-		return "", fmt.Errorf("key normalization result is empty")
+		return "", fmt.Errorf(
+			"key normalization result is empty: input %q normalized to %q (characters ?, [, ], . and leading or trailing separators are removed)",
+			keySlice, keyPath,
+		)
 	}
 
 	err := checkMaxSizeKey(keyPath)
@@ -80,3 +203,55 @@ func slicePathsConvertor(keySlice []string) (string, error) {
 
 	return keyPath, nil
 }
+
+// minHashedKeyLength - smallest MaxKeyLength leaving room for a readable prefix
+const minHashedKeyLength = 80
+
+// hashLongKey replaces a key longer than MaxKeyLength with its readable start and
+// the SHA-256 of the whole key, e.g. users:some_very_lo...:<64 hex digits>
+// Keys with * are patterns and are left as is.
+func (v *RedisGk) hashLongKey(key string) string {
+	if v == nil || v.maxKeyLength <= 0 || len(key) <= v.maxKeyLength || strings.Contains(key, "*") {
+		return key
+	}
+
+	sep := v.separator()
+	sum := sha256.Sum256([]byte(key))
+	hash := hex.EncodeToString(sum[:])
+
+	end := v.maxKeyLength - len(hash) - len(sep)
+	for end > 0 && !utf8.RuneStart(key[end]) {
+		end--
+	}
+	prefix := strings.TrimSuffix(key[:max(end, 0)], sep)
+	if prefix == "" {
+		return hash
+	}
+
+	return prefix + sep + hash
+}
+
+// matchPattern checks key against a Redis glob pattern
+// Only * is supported, the other glob characters are removed by key normalization
+func matchPattern(pattern, key string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == key
+	}
+
+	if !strings.HasPrefix(key, parts[0]) {
+		return false
+	}
+	key = key[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(key, part)
+		if idx < 0 {
+			return false
+		}
+		key = key[idx+len(part):]
+	}
+
+	return len(key) >= len(last) && strings.HasSuffix(key, last)
+}
@@ -0,0 +1,124 @@
+package redisgklib
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestHashLongKeyDeterministic(t *testing.T) {
+	v := &RedisGk{maxKeyLength: 100}
+	long := "users:" + strings.Repeat("профиль_", 20)
+
+	first := v.hashLongKey(long)
+	if first != v.hashLongKey(long) {
+		t.Fatal("hashing the same key twice gave different results")
+	}
+	if len(first) > 100 {
+		t.Errorf("hashed key has %d bytes, want at most 100", len(first))
+	}
+	if !strings.HasPrefix(first, "users:") {
+		t.Errorf("hashed key %q lost its readable prefix", first)
+	}
+	if !utf8.ValidString(first) {
+		t.Errorf("hashed key %q is not valid UTF-8", first)
+	}
+	if other := v.hashLongKey(long + "x"); other == first {
+		t.Error("different keys hashed to the same key")
+	}
+
+	if short := v.hashLongKey("users:1"); short != "users:1" {
+		t.Errorf("short key changed to %q", short)
+	}
+	pattern := "users:" + strings.Repeat("a", 200) + "*"
+	if got := v.hashLongKey(pattern); got != pattern {
+		t.Errorf("pattern changed to %q", got)
+	}
+	if got := (&RedisGk{}).hashLongKey(long); got != long {
+		t.Errorf("key hashed without MaxKeyLength: %q", got)
+	}
+}
+
+func TestValidateKeySeparator(t *testing.T) {
+	for _, sep := range []string{"", ":", "/", "::", "|"} {
+		if err := validateKeySeparator(sep); err != nil {
+			t.Errorf("separator %q rejected: %v", sep, err)
+		}
+	}
+	for _, sep := range []string{".", "*", "?", "[", "]", " ", "A", "a.b"} {
+		if err := validateKeySeparator(sep); err == nil {
+			t.Errorf("separator %q accepted", sep)
+		}
+	}
+}
+
+func TestKeySeparatorJoinAndCollapse(t *testing.T) {
+	v := &RedisGk{keySeparator: "/"}
+
+	key, err := v.slicePathsConvertor([]string{"Users", "1//profile/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "users/1/profile" {
+		t.Errorf("got %q, want %q", key, "users/1/profile")
+	}
+
+	pattern, err := v.prefixPatternConvertor([]string{"users", "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pattern != "users/1*" {
+		t.Errorf("pattern: got %q, want %q", pattern, "users/1*")
+	}
+}
+
+func TestEmptyNormalizationError(t *testing.T) {
+	_, err := (&RedisGk{}).slicePathsConvertor([]string{"...", "[?]"})
+	if err == nil {
+		t.Fatal("expected an error for a key made of stripped characters")
+	}
+	for _, want := range []string{"key normalization result is empty", `["..." "[?]"]`, `normalized to ""`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't contain %q", err, want)
+		}
+	}
+
+	strict := &RedisGk{strictKeyValidation: true}
+	_, err = strict.slicePathsConvertor([]string{"users", "..."})
+	if err == nil || !strings.Contains(err.Error(), `element 1 ("...")`) {
+		t.Errorf("strict validation: got %v, want an error naming element 1", err)
+	}
+	if _, err := strict.slicePathsConvertor([]string{"users", "1"}); err != nil {
+		t.Errorf("strict validation rejected a valid key: %v", err)
+	}
+}
+
+func TestPatternNormalizationMatchesKeys(t *testing.T) {
+	cases := []struct {
+		key    []string
+		prefix []string
+	}{
+		{[]string{"a", "b"}, []string{"a"}},
+		{[]string{"A.b", "c"}, []string{"A.b"}},
+		{[]string{"User [1]", "Profile?"}, []string{"User [1]"}},
+		{[]string{"a", "b"}, []string{"a", "b"}},
+	}
+
+	for _, sep := range []string{"", "/"} {
+		v := &RedisGk{keySeparator: sep}
+		for _, c := range cases {
+			key, err := v.slicePathsConvertor(c.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pattern, err := v.prefixPatternConvertor(c.prefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !matchPattern(pattern, key) {
+				t.Errorf("separator %q: key %q written with %q is not matched by pattern %q of %q",
+					sep, key, c.key, pattern, c.prefix)
+			}
+		}
+	}
+}
@@ -1,12 +1,24 @@
 package redisgklib
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
 	"strings"
+	"unicode/utf8"
 )
 
+// isEmptyConfig checks that no connection parameter is set
+// Fields are compared one by one since options may hold non-comparable values
+func isEmptyConfig(conf RedisConfConn) bool {
+	return conf.Host == "" &&
+		conf.Port == 0 &&
+		conf.User == "" &&
+		conf.Password == "" &&
+		conf.DB == 0
+}
+
 // validateRedisConfConn validates Redis connection configuration
 func validateRedisConfConn(conf RedisConfConn) error {
 	if conf.Host == "" {
@@ -28,7 +40,7 @@ func validateRedisConfConn(conf RedisConfConn) error {
 		return errors.New("port must be >= 1024 (privileged ports require additional permissions)")
 	}
 
-	if conf.Password == "" {
+	if conf.Password == "" && conf.CredentialsProvider == nil {
 		return errors.New("password is required")
 	}
 
@@ -78,14 +90,33 @@ func isValidHost(host string) bool {
 
 const maxSizeData = int(512 * 1024 * 1024) // 512 MB
 
-// checkMaxSizeData checks data size
-func checkMaxSizeData(data []byte) error {
-	if len(data) > maxSizeData {
-		return fmt.Errorf("data size (%d bytes) exceeds Redis limit (512 MB)", len(data))
+// checkValueSize checks object data size against MaxValueSize
+func (v *RedisGk) checkValueSize(data []byte) error {
+	if !v.skipSizeCheck && len(data) > v.maxValueSize {
+		return fmt.Errorf("data size (%d bytes) exceeds limit (%d bytes)", len(data), v.maxValueSize)
 	}
 	return nil
 }
 
+// fitStringValue applies the OnOversize policy to a string value over MaxValueSize
+// Truncation cuts at a UTF-8 character boundary, so the result may be a few bytes shorter
+func (v *RedisGk) fitStringValue(ctx context.Context, key, value string) (string, error) {
+	if v.skipSizeCheck || len(value) <= v.maxValueSize {
+		return value, nil
+	}
+	if v.onOversize != OversizeTruncate {
+		return "", fmt.Errorf("value size (%d bytes) exceeds limit (%d bytes)", len(value), v.maxValueSize)
+	}
+
+	end := v.maxValueSize
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+
+	logf(v.logger, ctx, "redisgk: value of key %s truncated from %d to %d bytes", key, len(value), end)
+	return value[:end], nil
+}
+
 // checkMaxSizeKey checks key size
 func checkMaxSizeKey(key string) error {
 	if len(key) > maxSizeData {
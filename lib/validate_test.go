@@ -0,0 +1,102 @@
+package redisgklib
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// testLogger - Logger collecting messages
+type testLogger struct {
+	messages []string
+}
+
+func (l *testLogger) Printf(_ context.Context, format string, args ...any) {
+	l.messages = append(l.messages, format)
+}
+
+func TestFitStringValueTruncatesAtRuneBoundary(t *testing.T) {
+	logger := &testLogger{}
+	v := &RedisGk{maxValueSize: 10, onOversize: OversizeTruncate, logger: logger}
+
+	// "ж" is 2 bytes, so byte 10 falls inside the 6th character
+	value := "abcdeжжжж"
+	got, err := v.fitStringValue(context.Background(), "k", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "abcdeжж" {
+		t.Errorf("got %q, want %q", got, "abcdeжж")
+	}
+	if !utf8.ValidString(got) || len(got) > 10 {
+		t.Errorf("result %q is %d bytes or not valid UTF-8", got, len(got))
+	}
+	if len(logger.messages) != 1 {
+		t.Errorf("got %d log messages, want 1", len(logger.messages))
+	}
+
+	// "€" is 3 bytes: bytes 8-10 hold it, the cut must step back to byte 8
+	got, err = v.fitStringValue(context.Background(), "k", "abcdefgh€€")
+	if err != nil || got != "abcdefgh" {
+		t.Errorf("got %q, %v, want %q", got, err, "abcdefgh")
+	}
+
+	if got, err := v.fitStringValue(context.Background(), "k", "short"); err != nil || got != "short" {
+		t.Errorf("value within the limit changed: %q, %v", got, err)
+	}
+}
+
+func TestFitStringValueErrorPolicy(t *testing.T) {
+	v := &RedisGk{maxValueSize: 4, onOversize: OversizeError}
+
+	_, err := v.fitStringValue(context.Background(), "k", "12345")
+	if err == nil || !strings.Contains(err.Error(), "exceeds limit") {
+		t.Errorf("got %v, want a size error", err)
+	}
+}
+
+func TestSkipSizeCheckStoresValue(t *testing.T) {
+	v, fake := newFakeRedisGk(t, RedisAdditionalOptions{MaxValueSize: 16})
+	large := strings.Repeat("x", 32)
+
+	if err := v.SetString([]string{"checked"}, large); err == nil {
+		t.Fatal("SetString over MaxValueSize succeeded")
+	}
+
+	trusted := v.SkipSizeCheck()
+	if err := trusted.SetString([]string{"trusted"}, large); err != nil {
+		t.Fatalf("SetString with SkipSizeCheck: %v", err)
+	}
+	if raw, _ := fake.get("trusted"); raw != large {
+		t.Errorf("stored %q, want the full value", raw)
+	}
+	if err := SetObj(trusted, []string{"obj"}, map[string]string{"body": large}); err != nil {
+		t.Errorf("SetObj with SkipSizeCheck: %v", err)
+	}
+
+	// The view doesn't change the instance
+	if err := v.SetString([]string{"checked"}, large); err == nil {
+		t.Error("SetString over MaxValueSize succeeded after SkipSizeCheck")
+	}
+}
+
+func benchmarkSetObj(b *testing.B, skip bool) {
+	v, _ := newFakeRedisGk(b)
+	if skip {
+		v = v.SkipSizeCheck()
+	}
+	value := map[string]string{"body": strings.Repeat("x", 1024)}
+
+	b.ResetTimer()
+	for range b.N {
+		if err := SetObj(v, []string{"bench"}, value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Compare with go test -run '^$' -bench SetObj: the fake store keeps the network out of the numbers
+func BenchmarkSetObj(b *testing.B) { benchmarkSetObj(b, false) }
+
+func BenchmarkSetObjSkipSizeCheck(b *testing.B) { benchmarkSetObj(b, true) }
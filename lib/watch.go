@@ -0,0 +1,158 @@
+package redisgklib
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// snapshotChannel - channel name of synthetic events emitted for existing keys
+const snapshotChannel = "snapshot"
+
+// SnapshotAndWatch returns channel that first receives EventTypeCreated events
+// for all existing keys under the pattern, then live events for matching keys.
+// The watcher is registered before scanning, so no change is missed in between.
+// Live events that arrive while the channel isn't read are buffered (100 events),
+// further ones are dropped and counted by DroppedEvents.
+// Returns an error if the first SCAN page can't be read. The channel is closed
+// when ctx is cancelled, the instance is closed or a later page can't be read
+// (the error is logged), so a closed channel may mean an incomplete snapshot.
+func (v *RedisGk) SnapshotAndWatch(ctx context.Context, patternPath []string) (<-chan KeyEvent, error) {
+	if v == nil {
+		return nil, fmt.Errorf("RedisGk instance is nil")
+	}
+	if v.listenerKeyEventManager == nil {
+		return nil, fmt.Errorf("listener key event manager is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pattern, err := v.prefixPatternConvertor(patternPath)
+	if err != nil {
+		return nil, fmt.Errorf("pattern conversion error: %w", err)
+	}
+
+	em := v.listenerKeyEventManager
+	watcher := em.addWatcher(pattern, 100)
+
+	// The first page is read here, so an unavailable Redis is reported to the caller
+	events, cursor, err := v.snapshotPage(pattern, 0)
+	if err != nil {
+		em.removeWatcher(watcher)
+		return nil, err
+	}
+
+	out := make(chan KeyEvent)
+
+	started := em.spawn(func() {
+		defer func() {
+			em.removeWatcher(watcher)
+			close(out)
+		}()
+
+		if !v.emitSnapshot(ctx, pattern, events, cursor, out) {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-em.ctx.Done():
+				return
+			case event, ok := <-watcher.ch:
+				if !ok {
+					return
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				case <-em.ctx.Done():
+					return
+				}
+			}
+		}
+	})
+	if !started {
+		em.removeWatcher(watcher)
+		return nil, fmt.Errorf("listener key event manager is stopped")
+	}
+
+	return out, nil
+}
+
+// snapshotPage returns synthetic created events for one SCAN page of keys under the pattern
+// and the cursor of the next page (0 - last page)
+func (v *RedisGk) snapshotPage(pattern string, cursor uint64) ([]KeyEvent, uint64, error) {
+	ctx, cancel := v.createContextWithTimeout()
+	defer cancel()
+
+	keys, next, err := v.redisClient.Scan(ctx, cursor, pattern, 100).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error scanning keys: %w", err)
+	}
+
+	var values []any
+	if len(keys) > 0 {
+		values, err = v.mget(ctx, keys)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error getting values: %w", err)
+		}
+	}
+
+	db := v.redisClient.Options().DB
+	events := make([]KeyEvent, 0, len(keys))
+	for i, key := range keys {
+		if isShadowKey(key) {
+			continue
+		}
+
+		event := KeyEvent{
+			Key:       key,
+			EventType: EventTypeCreated,
+			Timestamp: time.Now().UTC(),
+			Channel:   snapshotChannel,
+			DB:        db,
+		}
+		if i < len(values) {
+			if str, ok := values[i].(string); ok {
+				event.Value = str
+			}
+		}
+		events = append(events, event)
+	}
+
+	return events, next, nil
+}
+
+// emitSnapshot sends the events of the first page, then scans and sends the remaining pages
+// Returns false if ctx was cancelled, the listener was stopped or a page couldn't be read
+func (v *RedisGk) emitSnapshot(ctx context.Context, pattern string, events []KeyEvent, cursor uint64, out chan<- KeyEvent) bool {
+	em := v.listenerKeyEventManager
+
+	for {
+		for _, event := range events {
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return false
+			case <-em.ctx.Done():
+				return false
+			}
+		}
+
+		if cursor == 0 {
+			return true
+		}
+
+		var err error
+		events, cursor, err = v.snapshotPage(pattern, cursor)
+		if err != nil {
+			// An incomplete snapshot followed by live events would look complete, end the stream instead
+			logf(v.logger, ctx, "redisgk: snapshot of %s interrupted, closing the channel: %v", pattern, err)
+			return false
+		}
+	}
+}
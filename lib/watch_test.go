@@ -0,0 +1,60 @@
+package redisgklib
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSnapshotAndWatch(t *testing.T) {
+	v, prefix := newTestRedisGk(t)
+
+	seeded := map[string]string{}
+	for _, name := range []string{"a", "b", "c"} {
+		if err := v.SetString(testKey(prefix, name), "v_"+name, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		seeded[testKeyName(t, v, prefix, name)] = "v_" + name
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := v.SnapshotAndWatch(ctx, prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for len(seeded) > 0 {
+		event := waitForEvent(t, events, 5*time.Second, func(e KeyEvent) bool {
+			return e.Channel == snapshotChannel
+		})
+		want, ok := seeded[event.Key]
+		if !ok {
+			t.Fatalf("unexpected snapshot event for %s", event.Key)
+		}
+		if event.EventType != EventTypeCreated || event.Value != want {
+			t.Errorf("snapshot event %+v, want created with value %q", event, want)
+		}
+		delete(seeded, event.Key)
+	}
+
+	liveKey := testKeyName(t, v, prefix, "live")
+	if err := v.SetString(testKey(prefix, "live"), "new", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	waitForEvent(t, events, 5*time.Second, func(e KeyEvent) bool {
+		return e.Key == liveKey && e.Channel != snapshotChannel
+	})
+
+	cancel()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("channel not closed after ctx was cancelled")
+		}
+	}
+}